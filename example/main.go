@@ -1,3 +1,5 @@
+//go:generate go run .. -spec ../openapi.yml -out validators
+
 package main
 
 import (
@@ -72,7 +74,7 @@ func main() {
 	// validator can return two types of error
 	switch vErr := errs.(type) {
 	case validate.ValidationErrors:
-		for _, e := range errs.(validate.ValidationErrors) {
+		for _, e := range vErr.Sorted() {
 			fmt.Println(e)
 		}
 	default:
@@ -0,0 +1,74 @@
+package validators
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/beng90/spec2go/validate"
+	"github.com/go-playground/validator/v10"
+)
+
+type AddOfferRequest struct {
+	AdditionalInfo  []AddOfferRequestAdditionalInfoItem `json:"additionalInfo,omitempty"`
+	Brand           *string                             `json:"brand,omitempty"`
+	CategoryId      string                              `json:"categoryId"`
+	DefaultLanguage *string                             `json:"defaultLanguage,omitempty"`
+	ProductName     string                              `json:"productName"`
+	Variants        []AddOfferRequestVariantsItem       `json:"variants"`
+}
+
+type AddOfferRequestAdditionalInfoItem struct {
+	Id        *string  `json:"id,omitempty"`
+	ValuesIds []string `json:"valuesIds,omitempty"`
+}
+
+type AddOfferRequestVariantsItem struct {
+	Content   []AddOfferRequestVariantsItemContentItem `json:"content"`
+	Delivery  AddOfferRequestVariantsItemDelivery      `json:"delivery"`
+	Ean       *string                                  `json:"ean,omitempty"`
+	Inventory AddOfferRequestVariantsItemInventory     `json:"inventory"`
+	IsEnabled bool                                     `json:"isEnabled"`
+	Media     AddOfferRequestVariantsItemMedia         `json:"media"`
+	Price     string                                   `json:"price"`
+	Sku       *string                                  `json:"sku,omitempty"`
+	Tags      []AddOfferRequestVariantsItemTagsItem    `json:"tags,omitempty"`
+}
+
+type AddOfferRequestVariantsItemContentItem struct {
+	Description string `json:"description"`
+	Language    string `json:"language"`
+}
+
+type AddOfferRequestVariantsItemDelivery struct {
+	AdditionalInfo     *string `json:"additionalInfo,omitempty"`
+	DispatchTime       int32   `json:"dispatchTime"`
+	ShippingTemplateId string  `json:"shippingTemplateId"`
+}
+
+type AddOfferRequestVariantsItemInventory struct {
+	Size int32 `json:"size"`
+}
+
+type AddOfferRequestVariantsItemMedia struct {
+	Images []AddOfferRequestVariantsItemMediaImagesItem `json:"images"`
+}
+
+type AddOfferRequestVariantsItemMediaImagesItem struct {
+	SortOrder *int32 `json:"sortOrder,omitempty"`
+	Url       string `json:"url"`
+}
+
+type AddOfferRequestVariantsItemTagsItem struct {
+	Id      *string `json:"id,omitempty"`
+	ValueId *string `json:"valueId,omitempty"`
+}
+
+// UnmarshalAddOffer validates request and decodes its body.
+func UnmarshalAddOffer(v *validator.Validate, req *http.Request, ctx context.Context) (*AddOfferRequest, error) {
+	model := &AddOfferRequest{}
+	if err := validate.Unmarshal(v, req, ctx, AddOfferValidate, model); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
@@ -0,0 +1,715 @@
+package validators
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/beng90/spec2go/validate"
+	"github.com/go-playground/validator/v10"
+)
+
+type ParameterRule struct {
+	Name     string
+	In       string
+	Rule     string
+	ItemRule string
+	Pattern  *string
+}
+
+var AddOfferValidateSchema = validate.NewSchema(
+	[]validate.FieldRule{
+		{Field: "categoryId", Rule: "required,string,max=16", Pattern: validate.Pattern(`^\d+_\d+$`)},
+		{Field: "defaultLanguage", Rule: "omitempty,string,min=2,max=2", Pattern: validate.Pattern(`^[a-zA-Z]{2}$`)},
+		{Field: "productName", Rule: "required,string,min=1,max=255", Pattern: nil},
+		{Field: "brand", Rule: "omitempty,string", Pattern: nil},
+		{Field: "additionalInfo", Rule: "omitempty,uniqueItems", Pattern: nil},
+		{Field: "additionalInfo[].id", Rule: "omitempty,string", Pattern: nil},
+		{Field: "additionalInfo[].valuesIds", Rule: "omitempty", Pattern: nil},
+		{Field: "additionalInfo[].valuesIds[]", Rule: "omitempty,string", Pattern: nil},
+		{Field: "variants", Rule: "required,max=1", Pattern: nil},
+		{Field: "variants[].price", Rule: "required,string", Pattern: nil},
+		{Field: "variants[].inventory", Rule: "required", Pattern: nil},
+		{Field: "variants[].inventory.size", Rule: "required,integer,int32,min=1,max=4294967295", Pattern: nil},
+		{Field: "variants[].content", Rule: "required,min=1", Pattern: nil},
+		{Field: "variants[].content[].language", Rule: "required,string,min=2,max=2", Pattern: nil},
+		{Field: "variants[].content[].description", Rule: "required,string,min=1,max=1024", Pattern: nil},
+		{Field: "variants[].isEnabled", Rule: "required,boolean", Pattern: nil},
+		{Field: "variants[].ean", Rule: "omitempty,string,min=13,max=13", Pattern: validate.Pattern(`^(\d{13})?$`)},
+		{Field: "variants[].sku", Rule: "omitempty,string", Pattern: nil},
+		{Field: "variants[].tags", Rule: "omitempty,uniqueItems", Pattern: nil},
+		{Field: "variants[].tags[].id", Rule: "omitempty,string", Pattern: nil},
+		{Field: "variants[].tags[].valueId", Rule: "omitempty,string", Pattern: nil},
+		{Field: "variants[].media", Rule: "required", Pattern: nil},
+		{Field: "variants[].media.images", Rule: "required,min=1", Pattern: nil},
+		{Field: "variants[].media.images[].url", Rule: "required,string,url,max=255", Pattern: nil},
+		{Field: "variants[].media.images[].sortOrder", Rule: "omitempty,integer,int32,min=1,max=64", Pattern: nil},
+		{Field: "variants[].delivery", Rule: "required", Pattern: nil},
+		{Field: "variants[].delivery.dispatchTime", Rule: "required,integer,int32,min=1,max=64", Pattern: nil},
+		{Field: "variants[].delivery.shippingTemplateId", Rule: "required,string,uuid", Pattern: nil},
+		{Field: "variants[].delivery.additionalInfo", Rule: "omitempty,string", Pattern: nil},
+	},
+	[]validate.FieldRule{
+		{Field: ""},
+		{Field: "additionalInfo[]"},
+		{Field: "variants[].delivery"},
+		{Field: "variants[].media.images[]"},
+		{Field: "variants[].tags[]"},
+	},
+	nil,
+)
+
+func AddOfferValidate(v *validator.Validate, req *http.Request, ctx context.Context) error {
+	return AddOfferValidateSchema.Validate(v, req, ctx)
+}
+
+var AddOfferValidateResponses = []validate.Response{
+	{
+		Status:      "202",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "data", Rule: "required", Pattern: nil},
+			{Field: "data.jobId", Rule: "required,string,uuid,min=36,max=36", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: "data"},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "400",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "401",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+}
+
+func AddOfferValidateResponse(v *validator.Validate, res *http.Response, ctx context.Context) error {
+	return validate.ValidateResponse(v, res, ctx, AddOfferValidateResponses)
+}
+
+var GetOffersValidateParametersRules = []ParameterRule{
+	{"itemsPerPage", "query", "omitempty,integer,int32,oneof=10 20 50 100", "", nil},
+	{"page", "query", "omitempty,integer,int32,min=1,max=99999", "", nil},
+	{"updatedAt[from]", "query", "omitempty,string,format=date-time", "", nil},
+}
+
+func GetOffersValidateParameters(v *validator.Validate, req *http.Request, ctx context.Context) error {
+	parameterValidator := validate.NewParameterValidator(v, req, ctx, "/offers")
+
+	for _, pRule := range GetOffersValidateParametersRules {
+		parameterValidator.AddRule(pRule.Name, pRule.In, pRule.Rule, pRule.ItemRule, pRule.Pattern)
+	}
+
+	return parameterValidator.Validate()
+}
+
+var GetOffersValidateResponses = []validate.Response{
+	{
+		Status:      "200",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "data", Rule: "required", Pattern: nil},
+			{Field: "data[].id", Rule: "required,string,min=1,max=36", Pattern: nil},
+			{Field: "data[].createdAt", Rule: "required,string,format=date-time", Pattern: nil},
+			{Field: "data[].updatedAt", Rule: "required,string,format=date-time", Pattern: nil},
+			{Field: "data[].expireAt", Rule: "required,string,format=date-time", Pattern: nil},
+			{Field: "data[].price", Rule: "required,string", Pattern: nil},
+			{Field: "data[].status", Rule: "required,string,oneof=active inactive", Pattern: nil},
+			{Field: "data[].inventory", Rule: "required", Pattern: nil},
+			{Field: "data[].inventory.size", Rule: "required,integer,int32,min=1,max=4294967295", Pattern: nil},
+			{Field: "data[].inventory.sold", Rule: "required,integer,int32,min=1,max=4294967295", Pattern: nil},
+			{Field: "data[].product", Rule: "required", Pattern: nil},
+			{Field: "data[].product.id", Rule: "required,string,min=14,max=14", Pattern: nil},
+			{Field: "data[].product.name", Rule: "required,string,min=1,max=255", Pattern: nil},
+			{Field: "meta", Rule: "required", Pattern: nil},
+			{Field: "meta.page", Rule: "required,integer,int32,min=1", Pattern: nil},
+			{Field: "meta.itemsPerPage", Rule: "required,integer,int32", Pattern: nil},
+			{Field: "meta.totalResults", Rule: "required,integer,int32", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+			{Field: "data[]"},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "400",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "401",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+}
+
+func GetOffersValidateResponse(v *validator.Validate, res *http.Response, ctx context.Context) error {
+	return validate.ValidateResponse(v, res, ctx, GetOffersValidateResponses)
+}
+
+var GetJobValidateParametersRules = []ParameterRule{
+	{"jobId", "path", "required,string,uuid,min=36,max=36", "", nil},
+}
+
+func GetJobValidateParameters(v *validator.Validate, req *http.Request, ctx context.Context) error {
+	parameterValidator := validate.NewParameterValidator(v, req, ctx, "/jobs/{jobId}")
+
+	for _, pRule := range GetJobValidateParametersRules {
+		parameterValidator.AddRule(pRule.Name, pRule.In, pRule.Rule, pRule.ItemRule, pRule.Pattern)
+	}
+
+	return parameterValidator.Validate()
+}
+
+var GetJobValidateResponses = []validate.Response{
+	{
+		Status:      "200",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "data", Rule: "required", Pattern: nil},
+			{Field: "data.jobId", Rule: "required,string,uuid", Pattern: nil},
+			{Field: "data.status", Rule: "required,string,oneof=pending processing complete", Pattern: nil},
+			{Field: "data.elements", Rule: "required,min=1", Pattern: nil},
+			{Field: "data.elements[].resourceId", Rule: "required,string,uuid,min=36,max=36", Pattern: nil},
+			{Field: "data.elements[].resourceType", Rule: "required,string,min=1,max=36", Pattern: nil},
+			{Field: "data.elements[].status", Rule: "required,string,oneof=pending processing complete", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: "data"},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "400",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "401",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "404",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+}
+
+func GetJobValidateResponse(v *validator.Validate, res *http.Response, ctx context.Context) error {
+	return validate.ValidateResponse(v, res, ctx, GetJobValidateResponses)
+}
+
+var GetOfferValidateParametersRules = []ParameterRule{
+	{"offerId", "path", "required,integer,int32,min=1,max=4294967295", "", nil},
+}
+
+func GetOfferValidateParameters(v *validator.Validate, req *http.Request, ctx context.Context) error {
+	parameterValidator := validate.NewParameterValidator(v, req, ctx, "/offers/{offerId}")
+
+	for _, pRule := range GetOfferValidateParametersRules {
+		parameterValidator.AddRule(pRule.Name, pRule.In, pRule.Rule, pRule.ItemRule, pRule.Pattern)
+	}
+
+	return parameterValidator.Validate()
+}
+
+var GetOfferValidateResponses = []validate.Response{
+	{
+		Status:      "200",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "data", Rule: "required", Pattern: nil},
+			{Field: "data.id", Rule: "required,string,min=1,max=36", Pattern: nil},
+			{Field: "data.createdAt", Rule: "required,string,format=date-time", Pattern: nil},
+			{Field: "data.updatedAt", Rule: "required,string,format=date-time", Pattern: nil},
+			{Field: "data.expireAt", Rule: "required,string,format=date-time", Pattern: nil},
+			{Field: "data.price", Rule: "required,string", Pattern: nil},
+			{Field: "data.status", Rule: "required,string,oneof=active inactive", Pattern: nil},
+			{Field: "data.inventory", Rule: "required", Pattern: nil},
+			{Field: "data.inventory.size", Rule: "required,integer,int32,min=1,max=4294967295", Pattern: nil},
+			{Field: "data.inventory.sold", Rule: "required,integer,int32,min=1,max=4294967295", Pattern: nil},
+			{Field: "data.product", Rule: "required", Pattern: nil},
+			{Field: "data.product.id", Rule: "required,string,min=14,max=14", Pattern: nil},
+			{Field: "data.product.name", Rule: "required,string,min=1,max=255", Pattern: nil},
+			{Field: "data.product.categoryId", Rule: "required,string", Pattern: nil},
+			{Field: "data.product.categoryTree", Rule: "required", Pattern: nil},
+			{Field: "data.product.categoryTree[].id", Rule: "required,string,max=36", Pattern: nil},
+			{Field: "data.product.categoryTree[].name", Rule: "required,string,max=128", Pattern: nil},
+			{Field: "data.product.ean", Rule: "required,string", Pattern: nil},
+			{Field: "data.product.sku", Rule: "required,string", Pattern: nil},
+			{Field: "data.product.description", Rule: "required,string", Pattern: nil},
+			{Field: "data.product.additionalInfo", Rule: "omitempty,uniqueItems", Pattern: nil},
+			{Field: "data.product.additionalInfo[].id", Rule: "required,string", Pattern: nil},
+			{Field: "data.product.additionalInfo[].valuesIds", Rule: "required", Pattern: nil},
+			{Field: "data.product.additionalInfo[].valuesIds[]", Rule: "omitempty,string", Pattern: nil},
+			{Field: "data.product.tags", Rule: "omitempty,uniqueItems", Pattern: nil},
+			{Field: "data.product.tags[].id", Rule: "required,string", Pattern: nil},
+			{Field: "data.product.tags[].valueId", Rule: "required,string", Pattern: nil},
+			{Field: "data.product.media", Rule: "required", Pattern: nil},
+			{Field: "data.product.media.images", Rule: "required,min=1", Pattern: nil},
+			{Field: "data.product.media.images[].url", Rule: "required,string,url,max=255", Pattern: nil},
+			{Field: "data.product.media.images[].sortOrder", Rule: "omitempty,integer,int32,min=1,max=64", Pattern: nil},
+			{Field: "data.product.delivery", Rule: "required", Pattern: nil},
+			{Field: "data.product.delivery.dispatchTime", Rule: "required,integer,int32,min=1,max=64", Pattern: nil},
+			{Field: "data.product.delivery.shippingTemplateId", Rule: "required,string,uuid", Pattern: nil},
+			{Field: "data.product.delivery.additionalInfo", Rule: "omitempty,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: "data"},
+			{Field: "data.product.additionalInfo[]"},
+			{Field: "data.product.delivery"},
+			{Field: "data.product.media.images[]"},
+			{Field: "data.product.tags[]"},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "400",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "401",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "404",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+}
+
+func GetOfferValidateResponse(v *validator.Validate, res *http.Response, ctx context.Context) error {
+	return validate.ValidateResponse(v, res, ctx, GetOfferValidateResponses)
+}
+
+var GetProductCategoriesValidateResponses = []validate.Response{
+	{
+		Status:      "200",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "data", Rule: "required", Pattern: nil},
+			{Field: "data[].id", Rule: "required,string,max=36", Pattern: nil},
+			{Field: "data[].name", Rule: "required,string,max=128", Pattern: nil},
+		},
+		AdditionalProperties: nil,
+		Compositions:         nil,
+	},
+	{
+		Status:      "400",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "401",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+}
+
+func GetProductCategoriesValidateResponse(v *validator.Validate, res *http.Response, ctx context.Context) error {
+	return validate.ValidateResponse(v, res, ctx, GetProductCategoriesValidateResponses)
+}
+
+var GetProductFeaturesValidateResponses = []validate.Response{
+	{
+		Status:      "200",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "data", Rule: "required", Pattern: nil},
+			{Field: "data[].id", Rule: "required,string", Pattern: nil},
+			{Field: "data[].name", Rule: "required,string", Pattern: nil},
+			{Field: "data[].isVariantFeature", Rule: "required,boolean", Pattern: nil},
+			{Field: "data[].categoriesIds", Rule: "required", Pattern: nil},
+			{Field: "data[].categoriesIds[]", Rule: "omitempty,string", Pattern: nil},
+		},
+		AdditionalProperties: nil,
+		Compositions:         nil,
+	},
+	{
+		Status:      "400",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "401",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "404",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+}
+
+func GetProductFeaturesValidateResponse(v *validator.Validate, res *http.Response, ctx context.Context) error {
+	return validate.ValidateResponse(v, res, ctx, GetProductFeaturesValidateResponses)
+}
+
+var GetProductFeaturesOptionsValidateParametersRules = []ParameterRule{
+	{"featureId", "path", "required,integer,int32,min=1,max=4294967295", "", nil},
+}
+
+func GetProductFeaturesOptionsValidateParameters(v *validator.Validate, req *http.Request, ctx context.Context) error {
+	parameterValidator := validate.NewParameterValidator(v, req, ctx, "/products/features/{featureId}")
+
+	for _, pRule := range GetProductFeaturesOptionsValidateParametersRules {
+		parameterValidator.AddRule(pRule.Name, pRule.In, pRule.Rule, pRule.ItemRule, pRule.Pattern)
+	}
+
+	return parameterValidator.Validate()
+}
+
+var GetProductFeaturesOptionsValidateResponses = []validate.Response{
+	{
+		Status:      "200",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "data", Rule: "required", Pattern: nil},
+			{Field: "data.id", Rule: "required,string", Pattern: nil},
+			{Field: "data.name", Rule: "required,string", Pattern: nil},
+			{Field: "data.options", Rule: "required", Pattern: nil},
+			{Field: "data.options[].id", Rule: "required,string,max=36", Pattern: nil},
+			{Field: "data.options[].name", Rule: "required,string,max=64", Pattern: nil},
+		},
+		AdditionalProperties: nil,
+		Compositions:         nil,
+	},
+	{
+		Status:      "400",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "401",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "404",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+}
+
+func GetProductFeaturesOptionsValidateResponse(v *validator.Validate, res *http.Response, ctx context.Context) error {
+	return validate.ValidateResponse(v, res, ctx, GetProductFeaturesOptionsValidateResponses)
+}
+
+var GetShippingsValidateResponses = []validate.Response{
+	{
+		Status:      "200",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "data", Rule: "required", Pattern: nil},
+			{Field: "data[].id", Rule: "omitempty,string,uuid,min=36,max=36", Pattern: nil},
+			{Field: "data[].name", Rule: "omitempty,string", Pattern: nil},
+			{Field: "data[].methods", Rule: "omitempty", Pattern: nil},
+			{Field: "data[].methods[].id", Rule: "required,string,uuid,min=36,max=36", Pattern: nil},
+			{Field: "data[].methods[].name", Rule: "required,string", Pattern: nil},
+			{Field: "data[].methods[].countryTo", Rule: "required,string,min=2,max=2", Pattern: validate.Pattern(`^[a-zA-Z]{2}$`)},
+			{Field: "data[].methods[].deliveryTimeFrom", Rule: "required,integer,int32,min=1", Pattern: nil},
+			{Field: "data[].methods[].deliveryTimeTo", Rule: "required,integer,int32,min=1", Pattern: nil},
+			{Field: "data[].methods[].pricePerFirstItem", Rule: "required,string", Pattern: nil},
+			{Field: "data[].methods[].pricePerNextItem", Rule: "required,string", Pattern: nil},
+			{Field: "data[].methods[].itemsAllowedInPackage", Rule: "required,integer,int32,min=1", Pattern: nil},
+		},
+		AdditionalProperties: nil,
+		Compositions:         nil,
+	},
+	{
+		Status:      "401",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "status", Rule: "required,integer", Pattern: nil},
+			{Field: "code", Rule: "required,string", Pattern: nil},
+			{Field: "message", Rule: "required,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+}
+
+func GetShippingsValidateResponse(v *validator.Validate, res *http.Response, ctx context.Context) error {
+	return validate.ValidateResponse(v, res, ctx, GetShippingsValidateResponses)
+}
+
+var GetTokenValidateResponses = []validate.Response{
+	{
+		Status:      "200",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "access_token", Rule: "required,string", Pattern: nil},
+			{Field: "token_type", Rule: "required,string", Pattern: nil},
+			{Field: "expires_in", Rule: "required,integer", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "400",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "error", Rule: "required,string", Pattern: nil},
+			{Field: "error_description", Rule: "omitempty,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "401",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "error", Rule: "required,string", Pattern: nil},
+			{Field: "error_description", Rule: "omitempty,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "403",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "error", Rule: "required,string", Pattern: nil},
+			{Field: "error_description", Rule: "omitempty,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "500",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "error", Rule: "required,string", Pattern: nil},
+			{Field: "error_description", Rule: "omitempty,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+	{
+		Status:      "503",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "error", Rule: "required,string", Pattern: nil},
+			{Field: "error_description", Rule: "omitempty,string", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{
+			{Field: ""},
+		},
+		Compositions: nil,
+	},
+}
+
+func GetTokenValidateResponse(v *validator.Validate, res *http.Response, ctx context.Context) error {
+	return validate.ValidateResponse(v, res, ctx, GetTokenValidateResponses)
+}
+
+// Routes maps operations to their validators, it is used by validate.Middleware.
+var Routes = []validate.Route{
+	{Method: "POST", Path: "/offers", Validate: AddOfferValidate},
+	{Method: "GET", Path: "/offers", ValidateParameters: GetOffersValidateParameters},
+	{Method: "GET", Path: "/jobs/{jobId}", ValidateParameters: GetJobValidateParameters},
+	{Method: "GET", Path: "/offers/{offerId}", ValidateParameters: GetOfferValidateParameters},
+	{Method: "GET", Path: "/products/categories"},
+	{Method: "GET", Path: "/products/features"},
+	{Method: "GET", Path: "/products/features/{featureId}", ValidateParameters: GetProductFeaturesOptionsValidateParameters},
+	{Method: "GET", Path: "/shippings"},
+	{Method: "POST", Path: "/oauth/token"},
+}
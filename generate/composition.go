@@ -0,0 +1,294 @@
+package generate
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"strconv"
+	"strings"
+)
+
+const (
+	SpecAllOf = "allOf"
+	SpecOneOf = "oneOf"
+	SpecAnyOf = "anyOf"
+	SpecNot   = "not"
+
+	// branchRoot is a path of the value validated by composition branch
+	branchRoot = "$"
+)
+
+// Composition is a oneOf, anyOf or not keyword validated at runtime against
+// value under Field path.
+type Composition struct {
+	Field         string
+	Keyword       string
+	Discriminator string
+	// Mapping maps discriminator values to branch names
+	Mapping  map[string]string
+	Branches []*Branch
+}
+
+// Branch holds rules of a single composition subschema. Paths are relative
+// to the composed value, empty path is the value itself.
+type Branch struct {
+	Name string
+	*RequestBody
+}
+
+// MergeAllOf merges allOf subschemas into the schema. Properties defined in
+// many subschemas are merged recursively, required fields are joined and the
+// stricter of numeric limits is kept.
+func (r *Resolver) MergeAllOf(schema yaml.MapSlice) (yaml.MapSlice, error) {
+	var allOf []interface{}
+	merged := yaml.MapSlice{}
+
+	for _, node := range schema {
+		if node.Key == SpecAllOf {
+			allOf, _ = node.Value.([]interface{})
+
+			continue
+		}
+
+		merged = append(merged, node)
+	}
+
+	if allOf == nil {
+		return schema, nil
+	}
+
+	for _, subschema := range allOf {
+		subschemaVal, ok := subschema.(yaml.MapSlice)
+		if !ok {
+			continue
+		}
+
+		resolved, release, err := r.Resolve(subschemaVal)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved, err = r.MergeAllOf(resolved)
+		release()
+		if err != nil {
+			return nil, err
+		}
+
+		merged = mergeSchemas(merged, resolved)
+	}
+
+	return merged, nil
+}
+
+func mergeSchemas(schema, other yaml.MapSlice) yaml.MapSlice {
+	result := append(yaml.MapSlice{}, schema...)
+
+	for _, node := range other {
+		index := -1
+		for i, item := range result {
+			if item.Key == node.Key {
+				index = i
+
+				break
+			}
+		}
+
+		if index < 0 {
+			result = append(result, node)
+
+			continue
+		}
+
+		switch node.Key {
+		case "properties":
+			result[index].Value = mergeProperties(result[index].Value.(yaml.MapSlice), node.Value.(yaml.MapSlice))
+		case "required":
+			required := append([]interface{}{}, result[index].Value.([]interface{})...)
+			result[index].Value = append(required, node.Value.([]interface{})...)
+		case "minimum", "minLength", "minItems":
+			if toFloat(node.Value) > toFloat(result[index].Value) {
+				result[index].Value = node.Value
+			}
+		case "maximum", "maxLength", "maxItems":
+			if toFloat(node.Value) < toFloat(result[index].Value) {
+				result[index].Value = node.Value
+			}
+		default:
+			result[index].Value = node.Value
+		}
+	}
+
+	return result
+}
+
+func mergeProperties(properties, other yaml.MapSlice) yaml.MapSlice {
+	result := append(yaml.MapSlice{}, properties...)
+
+	for _, property := range other {
+		merged := false
+		for i, item := range result {
+			if item.Key == property.Key {
+				// property declared twice has to match both schemas
+				result[i].Value = yaml.MapSlice{{Key: SpecAllOf, Value: []interface{}{item.Value, property.Value}}}
+				merged = true
+
+				break
+			}
+		}
+
+		if !merged {
+			result = append(result, property)
+		}
+	}
+
+	return result
+}
+
+func toFloat(value interface{}) float64 {
+	v, _ := toNumber(value)
+
+	return v
+}
+
+// getCompositions records oneOf, anyOf and not keywords of schema under path.
+func (b *RequestBody) getCompositions(schema yaml.MapSlice, path []string) error {
+	// models do not use compositions
+	if b.namedRefs {
+		return nil
+	}
+
+	for _, node := range schema {
+		switch node.Key {
+		case SpecOneOf, SpecAnyOf:
+			composition := &Composition{
+				Field:   strings.Join(path, "."),
+				Keyword: node.Key.(string),
+			}
+
+			for i, value := range node.Value.([]interface{}) {
+				subschema, err := getSubschema(value, b.resolver.version)
+				if err != nil {
+					return fmt.Errorf("%s %d: %w", node.Key, i, err)
+				}
+
+				branch, err := b.getBranch(subschema, strconv.Itoa(i))
+				if err != nil {
+					return err
+				}
+
+				composition.Branches = append(composition.Branches, branch)
+			}
+
+			getDiscriminator(composition, schema, node.Value.([]interface{}))
+			b.Compositions = append(b.Compositions, composition)
+		case SpecNot:
+			subschema, err := getSubschema(node.Value, b.resolver.version)
+			if err != nil {
+				return fmt.Errorf("%s: %w", SpecNot, err)
+			}
+
+			branch, err := b.getBranch(subschema, SpecNot)
+			if err != nil {
+				return err
+			}
+
+			b.Compositions = append(b.Compositions, &Composition{
+				Field:    strings.Join(path, "."),
+				Keyword:  SpecNot,
+				Branches: []*Branch{branch},
+			})
+		}
+	}
+
+	return nil
+}
+
+// getBranch walks subschema like a separate request body. Branch is named
+// after referenced component or its title, index is used otherwise.
+func (b *RequestBody) getBranch(subschema yaml.MapSlice, name string) (*Branch, error) {
+	if ref, ok := getRef(subschema); ok {
+		name = ref[strings.LastIndex(ref, "/")+1:]
+	} else {
+		for _, node := range subschema {
+			if title, ok := node.Value.(string); ok && node.Key == "title" {
+				name = title
+			}
+		}
+	}
+
+	body := newRequestBody(b.resolver)
+	body.recursiveRefs = b.recursiveRefs
+	if err := body.getJSONProperty(subschema, []string{branchRoot}, false); err != nil {
+		return nil, err
+	}
+
+	return &Branch{
+		Name:        name,
+		RequestBody: body.relative(),
+	}, nil
+}
+
+// relative returns copy of request body walked from branch root, with all
+// paths relative to it.
+func (b *RequestBody) relative() *RequestBody {
+	body := newRequestBody(b.resolver)
+
+	for name, param := range b.Properties {
+		param.Name = relativePath(name)
+		body.Properties[param.Name] = param
+	}
+
+	for name, param := range b.AdditionalProperties {
+		body.AdditionalProperties[relativePath(name)] = param
+	}
+
+	for _, composition := range b.Compositions {
+		composition.Field = relativePath(composition.Field)
+		body.Compositions = append(body.Compositions, composition)
+	}
+
+	return body
+}
+
+func relativePath(path string) string {
+	path = strings.TrimPrefix(path, branchRoot)
+
+	return strings.TrimPrefix(path, ".")
+}
+
+func getDiscriminator(composition *Composition, schema yaml.MapSlice, subschemas []interface{}) {
+	var discriminator yaml.MapSlice
+	for _, node := range schema {
+		if node.Key == "discriminator" {
+			discriminator, _ = node.Value.(yaml.MapSlice)
+		}
+	}
+
+	if discriminator == nil {
+		return
+	}
+
+	composition.Mapping = make(map[string]string)
+
+	for _, node := range discriminator {
+		switch node.Key {
+		case "propertyName":
+			composition.Discriminator = node.Value.(string)
+		case "mapping":
+			for _, mapping := range node.Value.(yaml.MapSlice) {
+				target := mapping.Value.(string)
+				composition.Mapping[mapping.Key.(string)] = target[strings.LastIndex(target, "/")+1:]
+			}
+		}
+	}
+
+	// without explicit mapping, names of referenced schemas are used
+	if len(composition.Mapping) == 0 {
+		for _, subschema := range subschemas {
+			subschemaVal, _ := subschema.(yaml.MapSlice)
+			if ref, ok := getRef(subschemaVal); ok {
+				name := ref[strings.LastIndex(ref, "/")+1:]
+				composition.Mapping[name] = name
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package generate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/generate"
+)
+
+const compositionSpec = `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              allOf:
+                - $ref: '#/components/schemas/Base'
+                - type: object
+                  properties:
+                    name:
+                      maxLength: 3
+                    variant:
+                      oneOf:
+                        - $ref: '#/components/schemas/Physical'
+                        - title: Digital
+                          type: object
+                          properties:
+                            url:
+                              type: string
+                      discriminator:
+                        propertyName: kind
+                    code:
+                      type: string
+                      not:
+                        enum: [admin]
+                  required: [variant]
+components:
+  schemas:
+    Base:
+      type: object
+      properties:
+        name:
+          type: string
+          maxLength: 5
+      required: [name]
+    Physical:
+      type: object
+      additionalProperties: false
+      properties:
+        weight:
+          type: integer
+      required: [weight]
+`
+
+func TestGenerate_AllOf(t *testing.T) {
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, compositionSpec))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"name":    "required,string,max=3",
+		"variant": "required",
+		"code":    "omitempty,string",
+	}, getRules(validators[0]))
+}
+
+func TestGenerate_Compositions(t *testing.T) {
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, compositionSpec))
+
+	assert.Nil(t, err)
+
+	compositions := validators[0].Compositions
+	assert.Len(t, compositions, 2)
+
+	oneOf := compositions[0]
+	assert.Equal(t, "variant", oneOf.Field)
+	assert.Equal(t, "oneOf", oneOf.Keyword)
+	assert.Equal(t, "kind", oneOf.Discriminator)
+	assert.Equal(t, map[string]string{"Physical": "Physical"}, oneOf.Mapping)
+	assert.Len(t, oneOf.Branches, 2)
+
+	physical := oneOf.Branches[0]
+	assert.Equal(t, "Physical", physical.Name)
+	assert.Equal(t, "required,integer", physical.Properties["weight"].Rules().String())
+	assert.Contains(t, physical.AdditionalProperties, "")
+
+	digital := oneOf.Branches[1]
+	assert.Equal(t, "Digital", digital.Name)
+	assert.Equal(t, "omitempty,string", digital.Properties["url"].Rules().String())
+
+	not := compositions[1]
+	assert.Equal(t, "code", not.Field)
+	assert.Equal(t, "not", not.Keyword)
+	assert.Equal(t, "omitempty,oneof=admin", not.Branches[0].Properties[""].Rules().String())
+}
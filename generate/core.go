@@ -1,100 +1,482 @@
 package generate
 
 import (
+	"errors"
+	"fmt"
+	"go/token"
 	"gopkg.in/yaml.v2"
 	"strings"
 )
 
 const (
+	SpecPaths       = "paths"
+	SpecOperationId = "operationId"
 	SpecParameters  = "parameters"
 	SpecRequestBody = "requestBody"
+
+	// SpecErrorMessage is a message of any failed rule of schema and
+	// SpecErrorMessages are messages of rules keyed by schema keyword
+	SpecErrorMessage  = "x-error-message"
+	SpecErrorMessages = "x-error-messages"
+
+	// SpecGoName overrides Go name of operation derived from operationId
+	SpecGoName = "x-go-name"
+
+	// SpecValidate are validator tags appended to rules of schema, e.g.
+	// constraints which cannot be expressed with OpenAPI keywords
+	SpecValidate = "x-validate"
+
+	specExtensionPrefix = "x-"
 )
 
+var (
+	ErrUnknownOperation = errors.New("unknown operation")
+	// ErrDuplicateOperation is returned when Go names of operations are equal,
+	// generated code would not compile
+	ErrDuplicateOperation = errors.New("duplicate operation name")
+	// ErrDuplicateIdentifier is returned when generated code would declare
+	// the same identifier twice, e.g. for component schema named like request
+	// model of an operation
+	ErrDuplicateIdentifier = errors.New("duplicate identifier")
+	// ErrInvalidGoName is returned for x-go-name which is not a Go identifier
+	ErrInvalidGoName = errors.New("invalid Go name")
+)
+
+var SpecMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
 type Validator struct {
-	Name       string
-	Parameters map[string]*Parameter
+	Name string
+	// OperationId is an operationId declared in spec
+	OperationId string
+	// Operation is a Go name of operation used as a prefix of generated functions
+	Operation string
+	Method    string
+	Path      string
+	// Parameters are request body fields keyed by their path
+	Parameters Parameters
+	// AdditionalProperties are closed request body objects keyed by their path
+	AdditionalProperties map[string]*Parameter
+	// Compositions are request body oneOf, anyOf and not keywords
+	Compositions []*Composition
+	// Model is a type of request body model and Models are types generated for it
+	Model  string
+	Models []*Model
+	// RequestParameters are path, query, header and cookie parameters keyed by location and name
+	RequestParameters Parameters
+	// Responses are documented responses keyed by status code and content type
+	Responses []*Response
 }
 
-func getSchema(param *Parameter, schema yaml.MapSlice) {
+// getSchema sets keywords of schema to param. Keywords which differ between
+// versions are read as version defines them.
+func getSchema(param *Parameter, schema yaml.MapSlice, version string) {
+	var exclusiveMin, exclusiveMax bool
+
 	for _, schemaProperty := range schema {
 		//fmt.Println("schemaProperty", schemaProperty.Key, schemaProperty.Value)
 		switch schemaProperty.Key {
 		case "type":
-			param.Type = schemaProperty.Value.(string)
+			param.Type, param.Nullable = getSchemaType(schemaProperty.Value, version)
 		case "format":
 			param.Format = schemaProperty.Value.(string)
+		case SpecErrorMessage:
+			param.ErrorMessage, _ = schemaProperty.Value.(string)
+		case SpecErrorMessages:
+			messages, _ := schemaProperty.Value.(yaml.MapSlice)
+			for _, message := range messages {
+				if param.ErrorMessages == nil {
+					param.ErrorMessages = make(map[string]string)
+				}
+				param.ErrorMessages[fmt.Sprint(message.Key)] = fmt.Sprint(message.Value)
+			}
+		case "nullable":
+			// null is a type of OpenAPI 3.1
+			if nullable, ok := schemaProperty.Value.(bool); ok && nullable && version == Version30 {
+				param.Nullable = true
+			}
 		case "pattern":
 			param.Pattern = schemaProperty.Value.(string)
-		case "minimum", "minLength":
-			switch schemaProperty.Value.(type) {
-			case int:
-				v := float64(schemaProperty.Value.(int))
-				param.Min = &v
-			case float64:
-				v := float64(schemaProperty.Value.(float64))
+		case "enum":
+			for _, value := range schemaProperty.Value.([]interface{}) {
+				// null is allowed by nullable, not by enum rule
+				if value == nil {
+					continue
+				}
+				param.Enum = append(param.Enum, fmt.Sprint(value))
+			}
+		case "const":
+			if schemaProperty.Value != nil {
+				param.Enum = []string{fmt.Sprint(schemaProperty.Value)}
+			}
+		case "minimum":
+			if v, ok := toNumber(schemaProperty.Value); ok {
 				param.Min = &v
 			}
-		case "maximum", "maxLength":
-			switch schemaProperty.Value.(type) {
-			case int:
-				v := float64(schemaProperty.Value.(int))
-				param.Max = &v
-			case float64:
-				v := float64(schemaProperty.Value.(float64))
+		case "maximum":
+			if v, ok := toNumber(schemaProperty.Value); ok {
 				param.Max = &v
 			}
+		case "exclusiveMinimum":
+			// number in OpenAPI 3.1, boolean modifier of minimum in 3.0
+			if v, ok := toNumber(schemaProperty.Value); ok && version == Version31 {
+				param.ExclusiveMin = &v
+			}
+			exclusiveMin, _ = schemaProperty.Value.(bool)
+		case "exclusiveMaximum":
+			if v, ok := toNumber(schemaProperty.Value); ok && version == Version31 {
+				param.ExclusiveMax = &v
+			}
+			exclusiveMax, _ = schemaProperty.Value.(bool)
+		case "multipleOf":
+			if v, ok := toNumber(schemaProperty.Value); ok && v > 0 {
+				param.MultipleOf = &v
+			}
+		case "minLength":
+			if v, ok := schemaProperty.Value.(int); ok {
+				param.MinLength = &v
+			}
+		case "maxLength":
+			if v, ok := schemaProperty.Value.(int); ok {
+				param.MaxLength = &v
+			}
+		case "minItems":
+			if v, ok := schemaProperty.Value.(int); ok {
+				param.MinItems = &v
+			}
+		case "maxItems":
+			if v, ok := schemaProperty.Value.(int); ok {
+				param.MaxItems = &v
+			}
+		case "uniqueItems":
+			param.UniqueItems, _ = schemaProperty.Value.(bool)
+		default:
+			if name, ok := schemaProperty.Key.(string); ok && strings.HasPrefix(name, specExtensionPrefix) {
+				if param.Extensions == nil {
+					param.Extensions = make(map[string]interface{})
+				}
+				param.Extensions[name] = schemaProperty.Value
+			}
 		}
 	}
+
+	if version != Version30 {
+		return
+	}
+
+	// minimum and maximum of OpenAPI 3.0 are exclusive when boolean modifier is set
+	if exclusiveMin && param.Min != nil {
+		param.ExclusiveMin, param.Min = param.Min, nil
+	}
+
+	if exclusiveMax && param.Max != nil {
+		param.ExclusiveMax, param.Max = param.Max, nil
+	}
 }
 
-func walk(validators *[]Validator, spec yaml.MapSlice, path []string) {
+// getSchemaType returns type of schema. OpenAPI 3.1 allows a list of types,
+// null in such list makes schema nullable. Schema with many other types has no
+// type rule.
+func getSchemaType(value interface{}, version string) (schemaType string, nullable bool) {
+	switch v := value.(type) {
+	case string:
+		return v, false
+	case []interface{}:
+		if version != Version31 {
+			break
+		}
+
+		var types []string
+		for _, item := range v {
+			if item == "null" {
+				nullable = true
+
+				continue
+			}
+
+			if itemType, ok := item.(string); ok {
+				types = append(types, itemType)
+			}
+		}
+
+		if len(types) == 1 {
+			schemaType = types[0]
+		}
+	}
+
+	return
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+
+	return 0, false
+}
+
+func walk(resolver *Resolver, validators *[]Validator, spec yaml.MapSlice) error {
 	for _, node := range spec {
-		switch nodeVal := node.Value.(type) {
-		case string:
-			if node.Key.(string) == "operationId" {
-				path = append(path, node.Value.(string))
-			}
-			//fmt.Println(path)
-			//fmt.Println(node.Key, node, path)
-		case yaml.MapSlice:
-			//path = append(path, node.Key.(string))
-
-			if node.Key == SpecRequestBody {
-				//fmt.Println(node.Key, node.Value)
-				//fmt.Println("generateValidatorsFromRequestBody", path)
-				parameters := GetRequestBodyParameters(nodeVal, path)
-				*validators = append(*validators, Validator{
-					Name:       strings.Title(path[0]) + "Validate",
-					Parameters: parameters,
-				})
-
-				//for _, pam := range parameters {
-				//	fmt.Println()
-				//	fmt.Printf("%#v\n", pam)
-				//}
-				//generateValidatorsFromRequestBody(parameters)
-			}
-			walk(validators, nodeVal, path)
-			//path = nil
-		case []interface{}:
-			switch node.Key {
-			case SpecParameters:
-				//fmt.Println("generateValidatorsFromParameters", path)
-				//parameters := getParameters(nodeVal, path)
-				//generateValidatorsFromParameters(parameters)
+		switch node.Key {
+		case SpecPaths:
+			for _, pathItem := range node.Value.(yaml.MapSlice) {
+				err := walkPathItem(resolver, validators, pathItem.Key.(string), pathItem.Value.(yaml.MapSlice))
+				if err != nil {
+					return err
+				}
 			}
-		default:
-			//fmt.Printf("%T\n", node)
-			//fmt.Println(node)
-			//fmt.Println(node.Key, reflect.TypeOf(node).String())
 		}
-		//path = nil
 	}
+
+	return nil
+}
+
+func walkPathItem(resolver *Resolver, validators *[]Validator, path string, pathItem yaml.MapSlice) error {
+	pathItem, release, err := resolver.Resolve(pathItem)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	defer release()
+
+	// parameters defined for path are shared by all of its operations
+	var parameters []interface{}
+	for _, node := range pathItem {
+		if node.Key == SpecParameters {
+			parameters = node.Value.([]interface{})
+		}
+	}
+
+	for _, node := range pathItem {
+		method, ok := node.Key.(string)
+		if !ok || !isMethod(method) {
+			continue
+		}
+
+		err := walkOperation(resolver, validators, strings.ToUpper(method), path, parameters, node.Value.(yaml.MapSlice))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func walkOperation(resolver *Resolver, validators *[]Validator, method, path string, pathParameters []interface{}, operation yaml.MapSlice) (err error) {
+	validator := Validator{
+		Method: method,
+		Path:   path,
+	}
+
+	parameters := append([]interface{}{}, pathParameters...)
+	var requestBody, responses yaml.MapSlice
+
+	for _, node := range operation {
+		switch node.Key {
+		case SpecOperationId:
+			validator.OperationId, _ = node.Value.(string)
+		case SpecGoName:
+			validator.Operation, _ = node.Value.(string)
+			if !token.IsIdentifier(validator.Operation) {
+				return fmt.Errorf("%s %s: %w %q", method, path, ErrInvalidGoName, validator.Operation)
+			}
+		case SpecParameters:
+			parameters = append(parameters, node.Value.([]interface{})...)
+		case SpecRequestBody:
+			requestBody = node.Value.(yaml.MapSlice)
+		case SpecResponses:
+			responses, _ = node.Value.(yaml.MapSlice)
+		}
+	}
+
+	if validator.Operation == "" {
+		validator.Operation = operationName(validator.OperationId, method, path)
+	}
+	validator.Name = validator.Operation + "Validate"
+
+	if requestBody != nil {
+		body, err := GetRequestBody(resolver, requestBody)
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", method, path, err)
+		}
+
+		validator.Parameters = body.Properties
+		validator.AdditionalProperties = body.AdditionalProperties
+		validator.Compositions = body.Compositions
+		validator.Model, validator.Models = getRequestModels(validator.Operation+"Request", body)
+	}
+
+	validator.RequestParameters, err = getParameters(resolver, parameters)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+
+	validator.Responses, err = getResponses(resolver, responses)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+
+	*validators = append(*validators, validator)
+
+	return nil
+}
+
+// operationName returns Go name of operation, operations without operationId
+// are named after method and path.
+func operationName(operationId, method, path string) string {
+	if name := goName(operationId); name != "" {
+		return name
+	}
+
+	return goName(strings.ToLower(method) + " " + path)
+}
+
+// HasBody reports whether request body validator is generated, bodies
+// without properties, additionalProperties and compositions have no rules.
+func (v Validator) HasBody() bool {
+	return len(v.Parameters) > 0 || len(v.AdditionalProperties) > 0 || len(v.Compositions) > 0
+}
+
+// HasUnmarshal reports whether body has a model decoded after it is
+// validated by body validator.
+func (v Validator) HasUnmarshal() bool {
+	return v.Model != "" && v.HasBody()
+}
+
+// identifiers returns names declared by templates for validator.
+func (v *Validator) identifiers() []string {
+	var names []string
+
+	if v.HasBody() {
+		names = append(names, v.Name, v.Name+"Schema")
+	}
+
+	if len(v.RequestParameters) > 0 {
+		names = append(names, v.Name+"Parameters", v.Name+"ParametersRules")
+		if len(v.RequestParameters.Messages()) > 0 {
+			names = append(names, v.Name+"ParametersMessages")
+		}
+	}
+
+	if len(v.Responses) > 0 {
+		names = append(names, v.Name+"Response", v.Name+"Responses")
+	}
+
+	if v.HasUnmarshal() {
+		names = append(names, "Unmarshal"+v.Operation)
+	}
+
+	return names
+}
+
+// checkOperationNames reports operations with equal Go names.
+func checkOperationNames(validators []Validator) error {
+	byName := make(map[string]*Validator)
+	for i := range validators {
+		validator := &validators[i]
+
+		if other, ok := byName[validator.Operation]; ok {
+			return fmt.Errorf("%w %s: %s %s and %s %s", ErrDuplicateOperation, validator.Operation,
+				other.Method, other.Path, validator.Method, validator.Path)
+		}
+
+		byName[validator.Operation] = validator
+	}
+
+	return nil
 }
 
-func Generate(validators *[]Validator, spec yaml.MapSlice) []string {
-	var path []string
-	walk(validators, spec, path)
+func isMethod(name string) bool {
+	for _, method := range SpecMethods {
+		if method == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Options configure GenerateWith, zero values are replaced with defaults.
+type Options struct {
+	// Pattern converts patterns of schemas, e.g. ECMAPattern keeps them as
+	// they are, TranslatePattern by default
+	Pattern PatternFunc
+	// ValidateExtension is a name of extension with validator tags appended
+	// to rules of schema, SpecValidate by default
+	ValidateExtension string
+	// Tags checks names of validator tags of extension, DefaultTags() by
+	// default
+	Tags TagFunc
+}
+
+// Generate adds validators of spec operations with default options. Patterns
+// are translated from ECMA-262 to RE2 syntax.
+func Generate(validators *[]Validator, spec yaml.MapSlice) error {
+	return GenerateWith(validators, spec, Options{})
+}
+
+// GenerateWith adds validators of spec operations generated with options.
+func GenerateWith(validators *[]Validator, spec yaml.MapSlice, options Options) error {
+	if options.Pattern == nil {
+		options.Pattern = TranslatePattern
+	}
+
+	if options.ValidateExtension == "" {
+		options.ValidateExtension = SpecValidate
+	}
+
+	if options.Tags == nil {
+		options.Tags = DefaultTags()
+	}
+
+	if _, err := GetVersion(spec); err != nil {
+		return err
+	}
+
+	generated := []Validator{}
+	if err := walk(NewResolver(spec), &generated, spec); err != nil {
+		return err
+	}
+
+	if err := checkOperationNames(generated); err != nil {
+		return err
+	}
+
+	if err := translatePatterns(generated, options.Pattern); err != nil {
+		return err
+	}
+
+	if err := setTags(generated, options.ValidateExtension, options.Tags); err != nil {
+		return err
+	}
+
+	*validators = append(*validators, generated...)
+
+	return nil
+}
+
+// FilterOperations returns validators of given operation ids. All validators
+// are returned when no operation is given.
+func FilterOperations(validators []Validator, operations []string) ([]Validator, error) {
+	if len(operations) == 0 {
+		return validators, nil
+	}
+
+	byId := make(map[string]Validator)
+	for _, validator := range validators {
+		byId[validator.OperationId] = validator
+	}
+
+	filtered := make([]Validator, 0, len(operations))
+	for _, operation := range operations {
+		validator, ok := byId[operation]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownOperation, operation)
+		}
+
+		filtered = append(filtered, validator)
+	}
 
-	return path
+	return filtered, nil
 }
@@ -0,0 +1,94 @@
+package generate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/generate"
+)
+
+func TestGenerate_OperationNames(t *testing.T) {
+	spec := `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+    get:
+      operationId: get-offers.list
+  /offers/{offerId}/images:
+    put:
+      responses:
+        '204':
+          description: No content
+  /jobs:
+    get:
+      operationId: 2jobs
+    post:
+      operationId: addJob
+      x-go-name: CreateJob
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+
+	assert.Nil(t, err)
+
+	names := make(map[string]string)
+	for _, validator := range validators {
+		names[validator.Method+" "+validator.Path] = validator.Name
+	}
+
+	assert.Equal(t, map[string]string{
+		"POST /offers":                 "AddOfferValidate",
+		"GET /offers":                  "GetOffersListValidate",
+		"PUT /offers/{offerId}/images": "PutOffersOfferIdImagesValidate",
+		"GET /jobs":                    "X2jobsValidate",
+		"POST /jobs":                   "CreateJobValidate",
+	}, names)
+}
+
+func TestGenerate_DuplicateOperation(t *testing.T) {
+	testData := []struct {
+		spec string
+		want string
+	}{
+		{`
+paths:
+  /offers:
+    post:
+      operationId: add_offer
+  /offers/{id}:
+    put:
+      operationId: addOffer
+`, "duplicate operation name AddOffer: POST /offers and PUT /offers/{id}"},
+		{`
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+    put:
+      operationId: updateOffer
+      x-go-name: AddOffer
+`, "duplicate operation name AddOffer: POST /offers and PUT /offers"},
+		{`
+paths:
+  /offers:
+    post:
+      x-go-name: add-offer
+`, `POST /offers: invalid Go name "add-offer"`},
+	}
+
+	for _, tt := range testData {
+		validators := []generate.Validator{}
+		err := generate.Generate(&validators, getSpec(t, tt.spec))
+
+		assert.EqualError(t, err, tt.want)
+	}
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, testData[0].spec))
+
+	assert.True(t, errors.Is(err, generate.ErrDuplicateOperation))
+}
@@ -0,0 +1,385 @@
+package generate
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	SpecComponents = "components"
+	SpecSchemas    = "schemas"
+
+	schemaRefPrefix = "#/components/schemas/"
+)
+
+// Model is a Go type generated for an object schema. Type is set for
+// schemas which are not objects, e.g. `type Id string`.
+type Model struct {
+	Name   string
+	Type   string
+	Fields []*ModelField
+}
+
+type ModelField struct {
+	Name      string
+	JSONName  string
+	Type      string
+	OmitEmpty bool
+}
+
+// Models is the data of models template.
+type Models struct {
+	Components []*Model
+	Validators []Validator
+}
+
+func (m *Models) HasRequests() bool {
+	for _, validator := range m.Validators {
+		if validator.HasUnmarshal() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasValidators reports whether any validator function is generated.
+func (m *Models) HasValidators() bool {
+	for _, validator := range m.Validators {
+		if validator.HasBody() || len(validator.RequestParameters) > 0 || len(validator.Responses) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *Models) UsesTime() bool {
+	models := append([]*Model{}, m.Components...)
+	for _, validator := range m.Validators {
+		models = append(models, validator.Models...)
+	}
+
+	for _, model := range models {
+		if strings.Contains(model.Type, "time.Time") {
+			return true
+		}
+
+		for _, field := range model.Fields {
+			if strings.Contains(field.Type, "time.Time") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// GenerateModels returns models of all component schemas. Request body
+// models are already set in validators by Generate. Properties referencing
+// component schemas use their named types, so recursive schemas are allowed.
+func GenerateModels(validators []Validator, spec yaml.MapSlice) (*Models, error) {
+	resolver := NewResolver(spec)
+	models := &Models{Validators: validators}
+
+	// schemaOf is a name of component schema of model
+	schemaOf := make(map[*Model]string)
+
+	schemas, _ := resolver.Lookup("#/" + SpecComponents + "/" + SpecSchemas)
+	schemasVal, _ := schemas.(yaml.MapSlice)
+
+	for _, schema := range schemasVal {
+		name := schema.Key.(string)
+
+		body := newRequestBody(resolver)
+		body.namedRefs = true
+		subschema, err := getSubschema(schema.Value, resolver.version)
+		if err != nil {
+			return nil, fmt.Errorf("component schema %s: %w", name, err)
+		}
+
+		err = body.getJSONProperty(subschema, []string{branchRoot}, true)
+		if err != nil {
+			return nil, err
+		}
+
+		builder := newModelBuilder(body.relative())
+		root := builder.properties[""]
+
+		if root.Ref == "" && (root.IsObject || len(builder.children("")) > 0) {
+			builder.build(goName(name), "")
+		} else {
+			builder.models = append(builder.models, &Model{
+				Name: goName(name),
+				Type: builder.goType(root, "", goName(name)),
+			})
+		}
+
+		for _, model := range builder.models {
+			schemaOf[model] = name
+		}
+
+		models.Components = append(models.Components, builder.models...)
+	}
+
+	if err := checkIdentifiers(models, schemaOf); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+// reservedIdentifiers are declared by templates once for all operations.
+var reservedIdentifiers = []string{"ParameterRule", "Routes"}
+
+// checkIdentifiers reports identifiers declared by templates more than once,
+// e.g. component schema named like request model of an operation, generated
+// code would not compile. Models of components are reported with name of
+// their schema.
+func checkIdentifiers(models *Models, schemaOf map[*Model]string) error {
+	origins := make(map[string]string)
+	declare := func(origin string, names ...string) error {
+		for _, name := range names {
+			if other, ok := origins[name]; ok {
+				return fmt.Errorf("%w %s: %s and %s", ErrDuplicateIdentifier, name, other, origin)
+			}
+
+			origins[name] = origin
+		}
+
+		return nil
+	}
+
+	if err := declare("generated code", reservedIdentifiers...); err != nil {
+		return err
+	}
+
+	for i := range models.Validators {
+		validator := &models.Validators[i]
+		operation := fmt.Sprintf("operation %s %s", validator.Method, validator.Path)
+
+		if err := declare(operation, validator.identifiers()...); err != nil {
+			return err
+		}
+
+		for _, model := range validator.Models {
+			if err := declare("request body of "+operation, model.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, model := range models.Components {
+		if err := declare("component schema "+schemaOf[model], model.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getRequestModels returns models of request body. Body referencing component
+// schema does not need its own model.
+func getRequestModels(name string, body *RequestBody) (string, []*Model) {
+	if body.Ref != "" {
+		return goName(body.Ref), nil
+	}
+
+	if len(body.Properties) == 0 {
+		return "", nil
+	}
+
+	builder := newModelBuilder(body)
+	builder.build(name, "")
+
+	return name, builder.models
+}
+
+type modelBuilder struct {
+	properties map[string]*Parameter
+	additional map[string]*Parameter
+	models     []*Model
+}
+
+func newModelBuilder(body *RequestBody) *modelBuilder {
+	return &modelBuilder{
+		properties: body.Properties,
+		additional: body.AdditionalProperties,
+	}
+}
+
+// build adds struct model for object under path.
+func (m *modelBuilder) build(name, path string) {
+	model := &Model{Name: name}
+	m.models = append(m.models, model)
+
+	for _, childPath := range m.children(path) {
+		param := m.properties[childPath]
+		jsonName := childPath[len(path):]
+		jsonName = strings.TrimPrefix(jsonName, ".")
+
+		field := &ModelField{
+			Name:     goName(jsonName),
+			JSONName: jsonName,
+			Type:     m.goType(param, childPath, name+goName(jsonName)),
+		}
+
+		if !param.Required {
+			field.OmitEmpty = true
+		}
+
+		// struct cannot hold itself, required self reference is a pointer too
+		if (!param.Required || param.Nullable || field.Type == name) && isNilable(field.Type) == false {
+			field.Type = "*" + field.Type
+		}
+
+		model.Fields = append(model.Fields, field)
+	}
+
+	if len(model.Fields) == 0 {
+		model.Type = "struct{}"
+	}
+}
+
+// children returns sorted paths of direct properties of object under path.
+func (m *modelBuilder) children(path string) []string {
+	prefix := path + "."
+	if path == "" {
+		prefix = ""
+	}
+
+	var children []string
+	for name := range m.properties {
+		if name == "" || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		rest := name[len(prefix):]
+		if rest == "" || strings.Contains(rest, ".") || strings.HasSuffix(rest, "[]") {
+			continue
+		}
+
+		children = append(children, name)
+	}
+	sort.Strings(children)
+
+	return children
+}
+
+func (m *modelBuilder) goType(param *Parameter, path, typeName string) string {
+	if param.Ref != "" {
+		return goName(param.Ref)
+	}
+
+	switch SchemaType(param.Type) {
+	case TypeString:
+		switch SchemaFormat(param.Format) {
+		case FormatDateTime:
+			return "time.Time"
+		case FormatByte:
+			return "[]byte"
+		}
+
+		return "string"
+	case TypeInteger:
+		if param.Format == "int32" {
+			return "int32"
+		}
+
+		return "int64"
+	case TypeNumber:
+		if param.Format == "float" {
+			return "float32"
+		}
+
+		return "float64"
+	case TypeBoolean:
+		return "bool"
+	case TypeArray:
+		itemsPath := path + "[]"
+
+		if items, ok := m.properties[itemsPath]; ok {
+			return "[]" + m.goType(items, itemsPath, typeName+"Item")
+		}
+
+		if param.ItemsRef != "" {
+			return "[]" + goName(param.ItemsRef)
+		}
+
+		if m.isObject(itemsPath) {
+			m.build(typeName+"Item", itemsPath)
+
+			return "[]" + typeName + "Item"
+		}
+
+		return "[]interface{}"
+	}
+
+	if len(m.children(path)) > 0 {
+		m.build(typeName, path)
+
+		return typeName
+	}
+
+	if additional, ok := m.additional[path]; ok && additional != nil {
+		return "map[string]" + m.goType(additional, path+".*", typeName+"Value")
+	}
+
+	if param.IsObject || SchemaType(param.Type) == TypeObject {
+		return "map[string]interface{}"
+	}
+
+	return "interface{}"
+}
+
+func (m *modelBuilder) isObject(path string) bool {
+	if _, ok := m.additional[path]; ok {
+		return true
+	}
+
+	return len(m.children(path)) > 0
+}
+
+func isNilable(goType string) bool {
+	return strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") || goType == "interface{}"
+}
+
+// goName converts name to exported Go identifier.
+func goName(name string) string {
+	var result strings.Builder
+	upper := true
+
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+
+			continue
+		}
+
+		if result.Len() == 0 && unicode.IsDigit(r) {
+			result.WriteByte('X')
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+
+		result.WriteRune(r)
+	}
+
+	return result.String()
+}
+
+// schemaRef returns name of component schema referenced by schema.
+func schemaRef(schema yaml.MapSlice) string {
+	ref, ok := getRef(schema)
+	if !ok || !strings.HasPrefix(ref, schemaRefPrefix) {
+		return ""
+	}
+
+	return strings.TrimPrefix(ref, schemaRefPrefix)
+}
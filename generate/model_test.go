@@ -0,0 +1,207 @@
+package generate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/generate"
+)
+
+const modelSpec = `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Offer'
+  /tags:
+    post:
+      operationId: addTag
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                meta:
+                  type: object
+                  properties:
+                    color:
+                      type: string
+              required:
+                - name
+components:
+  schemas:
+    Id:
+      type: string
+    Offer:
+      type: object
+      properties:
+        id:
+          $ref: '#/components/schemas/Id'
+        count:
+          type: integer
+          format: int32
+        price:
+          type: number
+        createdAt:
+          type: string
+          format: date-time
+        tags:
+          type: array
+          items:
+            type: object
+            properties:
+              name:
+                type: string
+        labels:
+          type: object
+          additionalProperties:
+            type: string
+      required:
+        - id
+        - tags
+`
+
+func TestGenerateModels(t *testing.T) {
+	spec := getSpec(t, modelSpec)
+
+	var validators []generate.Validator
+	if err := generate.Generate(&validators, spec); err != nil {
+		t.Fatal(err)
+	}
+
+	models, err := generate.GenerateModels(validators, spec)
+	assert.NoError(t, err)
+	assert.True(t, models.HasRequests())
+	assert.True(t, models.UsesTime())
+
+	assert.Equal(t, []*generate.Model{
+		{Name: "Id", Type: "string"},
+		{Name: "Offer", Fields: []*generate.ModelField{
+			{Name: "Count", JSONName: "count", Type: "*int32", OmitEmpty: true},
+			{Name: "CreatedAt", JSONName: "createdAt", Type: "*time.Time", OmitEmpty: true},
+			{Name: "Id", JSONName: "id", Type: "Id"},
+			{Name: "Labels", JSONName: "labels", Type: "map[string]string", OmitEmpty: true},
+			{Name: "Price", JSONName: "price", Type: "*float64", OmitEmpty: true},
+			{Name: "Tags", JSONName: "tags", Type: "[]OfferTagsItem"},
+		}},
+		{Name: "OfferTagsItem", Fields: []*generate.ModelField{
+			{Name: "Name", JSONName: "name", Type: "*string", OmitEmpty: true},
+		}},
+	}, models.Components)
+
+	for _, validator := range validators {
+		switch validator.Operation {
+		case "AddOffer":
+			assert.Equal(t, "Offer", validator.Model)
+			assert.Empty(t, validator.Models)
+		case "AddTag":
+			assert.Equal(t, "AddTagRequest", validator.Model)
+			assert.Equal(t, []*generate.Model{
+				{Name: "AddTagRequest", Fields: []*generate.ModelField{
+					{Name: "Meta", JSONName: "meta", Type: "*AddTagRequestMeta", OmitEmpty: true},
+					{Name: "Name", JSONName: "name", Type: "string"},
+				}},
+				{Name: "AddTagRequestMeta", Fields: []*generate.ModelField{
+					{Name: "Color", JSONName: "color", Type: "*string", OmitEmpty: true},
+				}},
+			}, validator.Models)
+		default:
+			t.Errorf("unexpected validator %s", validator.Operation)
+		}
+	}
+}
+
+func TestGenerateModels_RecursiveComponent(t *testing.T) {
+	spec := getSpec(t, `
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        name:
+          type: string
+        parent:
+          $ref: '#/components/schemas/Node'
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Node'
+        labels:
+          type: object
+          additionalProperties:
+            $ref: '#/components/schemas/Node'
+      required:
+        - name
+        - parent
+`)
+
+	models, err := generate.GenerateModels(nil, spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*generate.Model{
+		{Name: "Node", Fields: []*generate.ModelField{
+			{Name: "Children", JSONName: "children", Type: "[]Node", OmitEmpty: true},
+			{Name: "Labels", JSONName: "labels", Type: "map[string]Node", OmitEmpty: true},
+			{Name: "Name", JSONName: "name", Type: "string"},
+			{Name: "Parent", JSONName: "parent", Type: "*Node"},
+		}},
+	}, models.Components)
+}
+
+func TestGenerateModels_DuplicateIdentifier(t *testing.T) {
+	operation := `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                meta:
+                  type: object
+                  properties:
+                    color:
+                      type: string
+components:
+  schemas:
+`
+
+	testData := []struct {
+		component string
+		want      string
+	}{
+		{"AddOfferRequest", "duplicate identifier AddOfferRequest: request body of operation POST /offers and component schema AddOfferRequest"},
+		{"addOfferRequestMeta", "duplicate identifier AddOfferRequestMeta: request body of operation POST /offers and component schema addOfferRequestMeta"},
+		{"AddOfferValidateSchema", "duplicate identifier AddOfferValidateSchema: operation POST /offers and component schema AddOfferValidateSchema"},
+		{"UnmarshalAddOffer", "duplicate identifier UnmarshalAddOffer: operation POST /offers and component schema UnmarshalAddOffer"},
+		{"Routes", "duplicate identifier Routes: generated code and component schema Routes"},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.component, func(t *testing.T) {
+			spec := getSpec(t, operation+"    "+tt.component+":\n      type: string\n")
+
+			var validators []generate.Validator
+			if err := generate.Generate(&validators, spec); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := generate.GenerateModels(validators, spec)
+
+			assert.True(t, errors.Is(err, generate.ErrDuplicateIdentifier))
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
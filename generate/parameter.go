@@ -1,17 +1,61 @@
 package generate
 
 import (
-	"fmt"
 	"gopkg.in/yaml.v2"
 )
 
-func getParameter(data yaml.MapSlice) Parameter {
+const (
+	InPath   = "path"
+	InQuery  = "query"
+	InHeader = "header"
+	InCookie = "cookie"
+)
+
+func getParameter(resolver *Resolver, data yaml.MapSlice) (Parameter, error) {
 	param := &Parameter{}
 
+	data, release, err := resolver.Resolve(data)
+	if err != nil {
+		return *param, err
+	}
+	defer release()
+
 	for _, property := range data {
 		switch property.Key {
 		case "schema":
-			getSchema(param, property.Value.(yaml.MapSlice))
+			schema, err := getSubschema(property.Value, resolver.version)
+			if err != nil {
+				return *param, err
+			}
+
+			schema, releaseSchema, err := resolver.Resolve(schema)
+			if err != nil {
+				return *param, err
+			}
+			defer releaseSchema()
+
+			getSchema(param, schema, resolver.version)
+
+			for _, schemaProperty := range schema {
+				if schemaProperty.Key != "items" {
+					continue
+				}
+
+				items, err := getSubschema(schemaProperty.Value, resolver.version)
+				if err != nil {
+					return *param, err
+				}
+
+				items, releaseItems, err := resolver.Resolve(items)
+				if err != nil {
+					return *param, err
+				}
+				defer releaseItems()
+
+				param.Items = &Parameter{}
+				getSchema(param.Items, items, resolver.version)
+				param.ArrayType = param.Items.Type
+			}
 		case "name":
 			param.Name = property.Value.(string)
 		case "in":
@@ -23,28 +67,37 @@ func getParameter(data yaml.MapSlice) Parameter {
 		}
 	}
 
-	//fmt.Printf("property %v\n", param)
+	// path parameters are always required
+	if param.In == InPath {
+		param.Required = true
+	}
+
+	if param.Items != nil {
+		param.Items.Name = param.Name + "[]"
+		param.Items.In = param.In
+	}
 
-	return *param
+	return *param, nil
 }
 
-func getParameters(data []interface{}, path []string) (parameters []Parameter) {
+// getParameters returns parameters keyed by location and name. Parameters
+// defined later override earlier ones, so operation parameters replace those
+// inherited from the path.
+func getParameters(resolver *Resolver, data []interface{}) (Parameters, error) {
+	parameters := make(Parameters)
+
 	for _, param := range data {
 		switch paramVal := param.(type) {
 		case yaml.MapSlice:
-			parameter := getParameter(paramVal)
-			path = append(path, parameter.Name)
-			parameters = append(parameters, parameter)
-		}
-	}
-
-	return
-}
+			parameter, err := getParameter(resolver, paramVal)
+			if err != nil {
+				return nil, err
+			}
 
-func generateValidatorsFromParameters(params []Parameter) {
-	for _, param := range params {
-		if param.Min != nil {
-			fmt.Printf("param %#v\n", param)
+			parameter.Order = len(parameters)
+			parameters[parameter.In+":"+parameter.Name] = &parameter
 		}
 	}
+
+	return parameters, nil
 }
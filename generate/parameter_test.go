@@ -0,0 +1,69 @@
+package generate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/generate"
+)
+
+func TestGenerate_Parameters(t *testing.T) {
+	spec := `
+paths:
+  '/offers/{offerId}':
+    parameters:
+      - $ref: '#/components/parameters/OfferId'
+      - name: lang
+        in: query
+        schema:
+          type: string
+    get:
+      operationId: getOffer
+      parameters:
+        - name: lang
+          in: query
+          required: true
+          schema:
+            type: string
+            pattern: ^[a-z]{2}$
+        - name: ids
+          in: query
+          schema:
+            type: array
+            items:
+              type: integer
+              maximum: 10
+components:
+  parameters:
+    OfferId:
+      name: offerId
+      in: path
+      schema:
+        type: integer
+        minimum: 1
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+
+	assert.Nil(t, err)
+	assert.Len(t, validators, 1)
+
+	validator := validators[0]
+	assert.Equal(t, "GetOfferValidate", validator.Name)
+	assert.Equal(t, "GET", validator.Method)
+	assert.Equal(t, "/offers/{offerId}", validator.Path)
+	assert.Len(t, validator.RequestParameters, 3)
+
+	offerId := validator.RequestParameters["path:offerId"]
+	assert.Equal(t, "required,integer,min=1", offerId.Rules().String())
+
+	lang := validator.RequestParameters["query:lang"]
+	assert.Equal(t, "required,string", lang.Rules().String())
+	assert.Equal(t, `^[a-z]{2}$`, lang.Pattern)
+
+	ids := validator.RequestParameters["query:ids"]
+	assert.Equal(t, "omitempty", ids.Rules().String())
+	assert.Equal(t, "omitempty,integer,max=10", ids.Items.Rules().String())
+}
@@ -0,0 +1,270 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPattern is returned for pattern which cannot be compiled, so it
+	// is reported by generator instead of failing at runtime.
+	ErrInvalidPattern = errors.New("invalid pattern")
+	// ErrUnsupportedPattern is returned for ECMA-262 constructs which have no
+	// RE2 equivalent, e.g. lookarounds and backreferences.
+	ErrUnsupportedPattern = errors.New("unsupported pattern")
+)
+
+// ecmaWhitespace are characters matched by \s of ECMA-262, RE2 matches only
+// ASCII whitespace.
+const ecmaWhitespace = `\t\n\v\f\r \x{a0}\x{1680}\x{2000}-\x{200a}\x{2028}\x{2029}\x{202f}\x{205f}\x{3000}\x{feff}`
+
+// unicodePropertyRegexp matches property names of \p and \P escapes which are
+// prefixed with their kind in ECMA-262, e.g. \p{Script=Greek}.
+var unicodePropertyRegexp = regexp.MustCompile(`^\{(?:Script|sc|Script_Extensions|scx|General_Category|gc)=(\w+)\}`)
+
+// PatternFunc converts pattern of schema to syntax of engine matching it at
+// runtime.
+type PatternFunc func(pattern string) (string, error)
+
+// ECMAPattern keeps pattern in ECMA-262 syntax. It is used with engine
+// implementing full ECMA-262 semantics set as validate.PatternEngine.
+func ECMAPattern(pattern string) (string, error) {
+	return pattern, nil
+}
+
+// TranslatePattern converts ECMA-262 pattern to RE2 syntax used by regexp
+// package. Unicode escapes, control characters, named groups, \s and empty
+// classes are translated, lookarounds and backreferences are not supported.
+func TranslatePattern(pattern string) (string, error) {
+	var translated strings.Builder
+	inClass := false
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		rest := pattern[i+1:]
+
+		switch {
+		case c == '\\' && rest != "":
+			escape, size, err := translateEscape(rest, inClass)
+			if err != nil {
+				return "", fmt.Errorf("%w: %s in %q", ErrUnsupportedPattern, err, pattern)
+			}
+
+			translated.WriteString(escape)
+			i += size
+		case inClass:
+			if c == ']' {
+				inClass = false
+			}
+
+			translated.WriteByte(c)
+		case c == '[':
+			switch {
+			case strings.HasPrefix(rest, "^]"):
+				// any character, also a line terminator
+				translated.WriteString(`(?s:.)`)
+				i += 2
+			case strings.HasPrefix(rest, "]"):
+				// empty class never matches
+				translated.WriteString(`[^\x00-\x{10ffff}]`)
+				i++
+			default:
+				inClass = true
+				translated.WriteByte(c)
+			}
+		case c == '(' && strings.HasPrefix(rest, "?"):
+			switch {
+			case strings.HasPrefix(rest, "?="), strings.HasPrefix(rest, "?!"):
+				return "", fmt.Errorf("%w: lookahead in %q", ErrUnsupportedPattern, pattern)
+			case strings.HasPrefix(rest, "?<="), strings.HasPrefix(rest, "?<!"):
+				return "", fmt.Errorf("%w: lookbehind in %q", ErrUnsupportedPattern, pattern)
+			case strings.HasPrefix(rest, "?<"):
+				translated.WriteString("(?P<")
+				i += 2
+			default:
+				translated.WriteByte(c)
+			}
+		default:
+			translated.WriteByte(c)
+		}
+	}
+
+	if _, err := regexp.Compile(translated.String()); err != nil {
+		return "", fmt.Errorf("%w %q: %v", ErrInvalidPattern, pattern, err)
+	}
+
+	return translated.String(), nil
+}
+
+// translateEscape translates escape sequence following a backslash and
+// returns its RE2 equivalent with number of consumed bytes.
+func translateEscape(escape string, inClass bool) (string, int, error) {
+	switch c := escape[0]; {
+	case c == 'u':
+		if strings.HasPrefix(escape, "u{") {
+			if end := strings.IndexByte(escape, '}'); end > 2 && isHex(escape[2:end]) {
+				return `\x` + escape[1:end+1], end + 1, nil
+			}
+		}
+
+		if len(escape) >= 5 && isHex(escape[1:5]) {
+			return `\x{` + escape[1:5] + `}`, 5, nil
+		}
+	case c == 'c':
+		if len(escape) >= 2 && isLetter(escape[1]) {
+			return fmt.Sprintf(`\x{%02x}`, escape[1]%32), 2, nil
+		}
+	case c == '0':
+		if len(escape) == 1 || !isDigit(escape[1]) {
+			return `\x00`, 1, nil
+		}
+
+		return "", 0, errors.New("octal escape")
+	case c >= '1' && c <= '9':
+		return "", 0, errors.New("backreference")
+	case c == 'k' && strings.HasPrefix(escape, "k<"):
+		return "", 0, errors.New("named backreference")
+	case c == 's':
+		if inClass {
+			return ecmaWhitespace, 1, nil
+		}
+
+		return `[` + ecmaWhitespace + `]`, 1, nil
+	case c == 'S' && !inClass:
+		return `[^` + ecmaWhitespace + `]`, 1, nil
+	case c == 'p' || c == 'P':
+		if match := unicodePropertyRegexp.FindStringSubmatch(escape[1:]); match != nil {
+			return `\` + string(c) + `{` + match[1] + `}`, 1 + len(match[0]), nil
+		}
+	case c == 'b' && inClass:
+		// backspace, not a word boundary
+		return `\x08`, 1, nil
+	}
+
+	return `\` + escape[:1], 1, nil
+}
+
+func isHex(s string) bool {
+	_, err := strconv.ParseUint(s, 16, 32)
+
+	return err == nil
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// translatePatterns converts patterns of all validators. Errors are reported
+// with location of schema declaring pattern.
+func translatePatterns(validators []Validator, translate PatternFunc) error {
+	for i := range validators {
+		validator := &validators[i]
+
+		err := eachParameter(validator, func(location string, param *Parameter) error {
+			if param.Pattern == "" {
+				return nil
+			}
+
+			pattern, err := translate(param.Pattern)
+			if err != nil {
+				return fmt.Errorf("%s: %w", location, err)
+			}
+
+			param.Pattern = pattern
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", validator.Method, validator.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// eachParameter calls fn for every parameter of validator with location of
+// schema declaring it.
+func eachParameter(validator *Validator, fn func(location string, param *Parameter) error) error {
+	body := &RequestBody{
+		Properties:           validator.Parameters,
+		AdditionalProperties: validator.AdditionalProperties,
+		Compositions:         validator.Compositions,
+	}
+
+	if err := eachBodyParameter(body, SpecRequestBody, fn); err != nil {
+		return err
+	}
+
+	for _, param := range validator.RequestParameters.Ordered() {
+		location := fmt.Sprintf("%s parameter %s", param.In, param.Name)
+
+		for _, p := range []*Parameter{param, param.Items} {
+			if p == nil {
+				continue
+			}
+
+			if err := fn(location, p); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, response := range validator.Responses {
+		location := fmt.Sprintf("%s %s %s", SpecResponses, response.Status, response.ContentType)
+
+		if err := eachBodyParameter(response.RequestBody, location, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func eachBodyParameter(body *RequestBody, location string, fn func(location string, param *Parameter) error) error {
+	if body == nil {
+		return nil
+	}
+
+	for _, param := range body.Properties.Ordered() {
+		if err := fn(strings.TrimSpace(location+" "+param.Name), param); err != nil {
+			return err
+		}
+	}
+
+	paths := make([]string, 0, len(body.AdditionalProperties))
+	for path := range body.AdditionalProperties {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		param := body.AdditionalProperties[path]
+		if param == nil {
+			continue
+		}
+
+		if err := fn(location+" "+path+" additionalProperties", param); err != nil {
+			return err
+		}
+	}
+
+	for _, composition := range body.Compositions {
+		for _, branch := range composition.Branches {
+			branchLocation := fmt.Sprintf("%s %s %s %s", location, composition.Field, composition.Keyword, branch.Name)
+
+			if err := eachBodyParameter(branch.RequestBody, branchLocation, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
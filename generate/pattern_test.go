@@ -0,0 +1,84 @@
+package generate_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/generate"
+)
+
+func TestTranslatePattern(t *testing.T) {
+	testData := []struct {
+		pattern string
+		want    string
+		matches []string
+		rejects []string
+	}{
+		{`^\d+_\d+$`, `^\d+_\d+$`, []string{"435_12"}, []string{"435-12"}},
+		{`^\u0041\u{1F600}$`, `^\x{0041}\x{1F600}$`, []string{"A😀"}, []string{"A"}},
+		{`^a\sb$`, `^a[` + `\t\n\v\f\r \x{a0}\x{1680}\x{2000}-\x{200a}\x{2028}\x{2029}\x{202f}\x{205f}\x{3000}\x{feff}` + `]b$`, []string{"a b", "a b"}, []string{"ab"}},
+		{`^[\S\s]$`, `^[\S` + `\t\n\v\f\r \x{a0}\x{1680}\x{2000}-\x{200a}\x{2028}\x{2029}\x{202f}\x{205f}\x{3000}\x{feff}` + `]$`, []string{"a", " "}, []string{""}},
+		{`^(?<year>\d{4})-\d{2}$`, `^(?P<year>\d{4})-\d{2}$`, []string{"2021-01"}, []string{"21-01"}},
+		{`^[^]$`, `^(?s:.)$`, []string{"\n"}, []string{""}},
+		{`^a[]$`, `^a[^\x00-\x{10ffff}]$`, nil, []string{"a"}},
+		{`^\cJ\0$`, `^\x{0a}\x00$`, []string{"\n\x00"}, nil},
+		{`^[\b\-]$`, `^[\x08\-]$`, []string{"\b", "-"}, []string{"b"}},
+		{`^\p{Script=Greek}+$`, `^\p{Greek}+$`, []string{"αβ"}, []string{"ab"}},
+		{`^\/offers$`, `^\/offers$`, []string{"/offers"}, nil},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.pattern, func(t *testing.T) {
+			pattern, err := generate.TranslatePattern(tt.pattern)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, pattern)
+
+			re := regexp.MustCompile(pattern)
+			for _, value := range tt.matches {
+				assert.True(t, re.MatchString(value), value)
+			}
+			for _, value := range tt.rejects {
+				assert.False(t, re.MatchString(value), value)
+			}
+		})
+	}
+}
+
+func TestTranslatePattern_Unsupported(t *testing.T) {
+	for _, pattern := range []string{`^(?=.*\d).{8,}$`, `^(?!a)`, `(?<=a)b`, `(?<!a)b`, `^(a)\1$`, `^(?<x>a)\k<x>$`, `\01`} {
+		_, err := generate.TranslatePattern(pattern)
+
+		assert.True(t, errors.Is(err, generate.ErrUnsupportedPattern), pattern)
+	}
+}
+
+func TestGenerate_UnsupportedPattern(t *testing.T) {
+	spec := `
+paths:
+  /offers:
+    get:
+      operationId: getOffers
+      parameters:
+        - name: code
+          in: query
+          schema:
+            type: string
+            pattern: '^(?=.*\d)\w+$'
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+
+	assert.True(t, errors.Is(err, generate.ErrUnsupportedPattern))
+	assert.Contains(t, err.Error(), "GET /offers: query parameter code: unsupported pattern: lookahead")
+	assert.Empty(t, validators)
+
+	err = generate.GenerateWith(&validators, getSpec(t, spec), generate.Options{Pattern: generate.ECMAPattern})
+
+	assert.Nil(t, err)
+	assert.Equal(t, `^(?=.*\d)\w+$`, validators[0].RequestParameters["query:code"].Pattern)
+}
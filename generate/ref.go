@@ -0,0 +1,162 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const SpecRef = "$ref"
+
+var (
+	ErrExternalRef = errors.New("only local $ref pointers are supported")
+	ErrRefNotFound = errors.New("$ref target not found")
+	ErrCircularRef = errors.New("circular $ref")
+)
+
+// Resolver resolves local JSON pointers (#/components/...) against the root
+// document. It keeps track of the references currently being expanded so
+// recursive schemas are reported instead of walked forever.
+type Resolver struct {
+	root  yaml.MapSlice
+	stack []string
+	// version is OpenAPI version of root document, keywords of schemas
+	// depend on it
+	version string
+}
+
+func NewResolver(root yaml.MapSlice) *Resolver {
+	version, err := GetVersion(root)
+	if err != nil {
+		version = Version30
+	}
+
+	return &Resolver{root: root, version: version}
+}
+
+// Resolve follows $ref of given node until it reaches a node without one.
+// Every followed reference stays on the resolver stack until returned release
+// function is called, so it must be called when node (and its children) are
+// processed.
+func (r *Resolver) Resolve(node yaml.MapSlice) (yaml.MapSlice, func(), error) {
+	entered := 0
+	release := func() {
+		r.stack = r.stack[:len(r.stack)-entered]
+	}
+
+	for {
+		ref, ok := getRef(node)
+		if !ok {
+			return node, release, nil
+		}
+
+		for _, visited := range r.stack {
+			if visited == ref {
+				chain := append(append([]string{}, r.stack...), ref)
+				release()
+
+				return nil, func() {}, fmt.Errorf("%w: %s", ErrCircularRef, strings.Join(chain, " -> "))
+			}
+		}
+
+		target, err := r.Lookup(ref)
+		if err != nil {
+			release()
+
+			return nil, func() {}, err
+		}
+
+		r.stack = append(r.stack, ref)
+		entered++
+
+		switch targetVal := target.(type) {
+		case yaml.MapSlice:
+			node = targetVal
+		default:
+			release()
+
+			return nil, func() {}, fmt.Errorf("%w: %s does not point to an object", ErrRefNotFound, ref)
+		}
+	}
+}
+
+// Walking reports whether $ref of node is being expanded, so resolving it
+// would report circular $ref.
+func (r *Resolver) Walking(node yaml.MapSlice) bool {
+	ref, ok := getRef(node)
+	if !ok {
+		return false
+	}
+
+	for _, visited := range r.stack {
+		if visited == ref {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Lookup returns value the JSON pointer points to.
+func (r *Resolver) Lookup(ref string) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("%w: %s", ErrExternalRef, ref)
+	}
+
+	pointer, err := url.PathUnescape(strings.TrimPrefix(ref, "#"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRefNotFound, ref)
+	}
+
+	var current interface{} = r.root
+	if pointer == "" {
+		return current, nil
+	}
+
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch currentVal := current.(type) {
+		case yaml.MapSlice:
+			found := false
+			for _, item := range currentVal {
+				if fmt.Sprint(item.Key) == token {
+					current = item.Value
+					found = true
+
+					break
+				}
+			}
+
+			if !found {
+				return nil, fmt.Errorf("%w: %s", ErrRefNotFound, ref)
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(currentVal) {
+				return nil, fmt.Errorf("%w: %s", ErrRefNotFound, ref)
+			}
+
+			current = currentVal[index]
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrRefNotFound, ref)
+		}
+	}
+
+	return current, nil
+}
+
+func getRef(node yaml.MapSlice) (string, bool) {
+	for _, item := range node {
+		if item.Key == SpecRef {
+			ref, ok := item.Value.(string)
+
+			return ref, ok
+		}
+	}
+
+	return "", false
+}
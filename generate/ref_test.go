@@ -0,0 +1,146 @@
+package generate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+
+	"github.com/beng90/spec2go/generate"
+)
+
+func getSpec(t *testing.T, spec string) yaml.MapSlice {
+	data := yaml.MapSlice{}
+	if err := yaml.Unmarshal([]byte(spec), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func getRules(validator generate.Validator) map[string]string {
+	rules := make(map[string]string)
+	for name, param := range validator.Parameters {
+		rules[name] = param.Rules().String()
+	}
+
+	return rules
+}
+
+const refSpec = `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      requestBody:
+        $ref: '#/components/requestBodies/Offer'
+components:
+  requestBodies:
+    Offer:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Offer'
+  schemas:
+    Offer:
+      type: object
+      properties:
+        name:
+          type: string
+          maxLength: 5
+        category:
+          $ref: '#/components/schemas/Category'
+        tags:
+          type: array
+          items:
+            $ref: '#/components/schemas/Tag'
+      required:
+        - name
+        - category
+    Category:
+      type: object
+      properties:
+        id:
+          $ref: '#/components/schemas/Id'
+      required:
+        - id
+    Tag:
+      type: object
+      properties:
+        id:
+          $ref: '#/components/schemas/Id'
+    Id:
+      type: string
+      pattern: ^\d+$
+`
+
+func TestGenerate_Ref(t *testing.T) {
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, refSpec))
+
+	assert.Nil(t, err)
+	assert.Len(t, validators, 1)
+	assert.Equal(t, map[string]string{
+		"name":        "required,string,max=5",
+		"category":    "required",
+		"category.id": "required,string",
+		"tags":        "omitempty",
+		"tags[].id":   "omitempty,string",
+	}, getRules(validators[0]))
+	assert.Equal(t, `^\d+$`, validators[0].Parameters["tags[].id"].Pattern)
+}
+
+func TestGenerate_CircularRef(t *testing.T) {
+	spec := `
+paths:
+  /categories:
+    post:
+      operationId: addCategory
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Category'
+components:
+  schemas:
+    Category:
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Category'
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+
+	assert.True(t, errors.Is(err, generate.ErrCircularRef))
+	assert.Contains(t, err.Error(), "#/components/schemas/Category -> #/components/schemas/Category")
+}
+
+func TestResolver_Lookup(t *testing.T) {
+	resolver := generate.NewResolver(getSpec(t, `
+components:
+  schemas:
+    a/b:
+      type: string
+    list:
+      - type: integer
+`))
+
+	value, err := resolver.Lookup("#/components/schemas/a~1b")
+	assert.Nil(t, err)
+	assert.Equal(t, yaml.MapSlice{{Key: "type", Value: "string"}}, value)
+
+	value, err = resolver.Lookup("#/components/schemas/list/0")
+	assert.Nil(t, err)
+	assert.Equal(t, yaml.MapSlice{{Key: "type", Value: "integer"}}, value)
+
+	_, err = resolver.Lookup("#/components/schemas/missing")
+	assert.True(t, errors.Is(err, generate.ErrRefNotFound))
+
+	_, err = resolver.Lookup("other.yml#/components/schemas/a")
+	assert.True(t, errors.Is(err, generate.ErrExternalRef))
+}
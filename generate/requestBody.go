@@ -1,146 +1,353 @@
 package generate
 
 import (
+	"errors"
 	"fmt"
 	"gopkg.in/yaml.v2"
 	"strings"
 )
 
-func getRequestBodyParameter(data yaml.MapSlice, paramName string) (param Parameter) {
+type RequestBody struct {
+	// Properties are body fields keyed by their path
+	Properties Parameters
+	// AdditionalProperties are closed objects keyed by their path. Nil value
+	// means no additional properties are allowed, otherwise extra values have
+	// to match the parameter.
+	AdditionalProperties map[string]*Parameter
+	// Compositions are oneOf, anyOf and not keywords checked at runtime
+	Compositions []*Composition
+	// Ref is a name of component schema used as request body schema
+	Ref string
+
+	resolver *Resolver
+	// namedRefs stops walk at properties referencing component schemas, models
+	// use named types of components, so recursive schemas terminate
+	namedRefs bool
+	// recursiveRefs stops walk at references which are being walked instead of
+	// reporting circular $ref, recursive parts of schema have no rules
+	recursiveRefs bool
+}
+
+func newRequestBody(resolver *Resolver) *RequestBody {
+	return &RequestBody{
+		Properties:           make(Parameters),
+		AdditionalProperties: make(map[string]*Parameter),
+		resolver:             resolver,
+	}
+}
+
+// resolve follows $ref of schema and merges its allOf subschemas.
+func (b *RequestBody) resolve(schema yaml.MapSlice) (yaml.MapSlice, func(), error) {
+	schema, release, err := b.resolver.Resolve(schema)
+	if err != nil {
+		return nil, release, err
+	}
+
+	merged, err := b.resolver.MergeAllOf(schema)
+	if err != nil {
+		release()
+
+		return nil, func() {}, err
+	}
+
+	return merged, release, nil
+}
+
+// ErrInvalidSchema is returned for schema which is not an object, or a boolean
+// in OpenAPI 3.1.
+var ErrInvalidSchema = errors.New("invalid schema")
+
+// getSubschema returns schema of value. Boolean schemas of OpenAPI 3.1 are
+// converted to objects, true accepts any value as an empty schema does and
+// false rejects any value as negation of an empty schema does.
+func getSubschema(value interface{}, version string) (yaml.MapSlice, error) {
+	switch schema := value.(type) {
+	case yaml.MapSlice:
+		return schema, nil
+	case bool:
+		if version != Version31 {
+			break
+		}
+
+		if schema {
+			return yaml.MapSlice{}, nil
+		}
+
+		return yaml.MapSlice{{Key: SpecNot, Value: yaml.MapSlice{}}}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, value)
+}
+
+func getRequestBodyParameter(data yaml.MapSlice, paramName, version string) (param Parameter) {
 	param.Name = paramName
 
 	for _, property := range data {
 		switch property.Key {
 		case "description":
 			param.Description = property.Value.(string)
-		case "type":
-			param.Type = property.Value.(string)
-		case "format":
-			param.Format = property.Value.(string)
-		case "items":
-			for _, arrayItem := range property.Value.(yaml.MapSlice) {
-				switch arrayItem.Key {
-				case "type":
-					param.ArrayType = arrayItem.Value.(string)
-				case "$ref":
-					fmt.Println("$ref is not supported yet")
-				}
-				//param.Format = property.Value.(string)
-			}
 		}
 	}
 
-	getSchema(&param, data)
-
-	//fmt.Printf("property %s\n", param)
+	getSchema(&param, data, version)
 
 	return
 }
 
-func GetRequestBodyParameters(data yaml.MapSlice, path []string) map[string]*Parameter {
-	properties := make(map[string]*Parameter)
+func GetRequestBody(resolver *Resolver, data yaml.MapSlice) (*RequestBody, error) {
+	body := newRequestBody(resolver)
+
+	data, release, err := resolver.Resolve(data)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
 	for _, content := range data {
 		switch content.Key {
 		case "content":
-			switch content.Value.(yaml.MapSlice)[0].Key {
-			case "application/json":
-				properties = getJSONContentProperties(content.Value.(yaml.MapSlice)[0].Value.(yaml.MapSlice))
+			for _, mediaType := range content.Value.(yaml.MapSlice) {
+				switch mediaType.Key {
+				case "application/json":
+					if err := body.getJSONContentProperties(mediaType.Value.(yaml.MapSlice)); err != nil {
+						return nil, err
+					}
+				}
 			}
-		case "$ref":
-			// TODO: add handler for reference type
-			fmt.Println("$ref is not supported yet")
 		}
 	}
 
-	return properties
+	return body, nil
 }
 
-func getJSONContentProperties(content yaml.MapSlice) map[string]*Parameter {
-	properties := make(map[string]*Parameter)
-
-	for _, node := range content[0].Value.(yaml.MapSlice) {
+func (b *RequestBody) getJSONContentProperties(content yaml.MapSlice) error {
+	for _, node := range content {
 		switch node.Key {
-		case "required":
-			for _, fieldName := range node.Value.([]interface{}) {
-				if _, ok := properties[fieldName.(string)]; ok {
-					properties[fieldName.(string)].Required = true
-				}
+		case "schema":
+			schema, err := getSubschema(node.Value, b.resolver.version)
+			if err != nil {
+				return err
 			}
+
+			b.Ref = schemaRef(schema)
+
+			schema, release, err := b.resolve(schema)
+			if err != nil {
+				return err
+			}
+			defer release()
+
+			if err := b.getCompositions(schema, []string{}); err != nil {
+				return err
+			}
+
+			return b.getJSONProperties(schema, []string{})
+		}
+	}
+
+	return nil
+}
+
+// getJSONProperties adds parameters for every property of an object schema.
+func (b *RequestBody) getJSONProperties(schema yaml.MapSlice, path []string) error {
+	if b.recursiveRefs && b.resolver.Walking(schema) {
+		return nil
+	}
+
+	schema, release, err := b.resolve(schema)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	required := getRequired(schema)
+
+	for _, node := range schema {
+		switch node.Key {
 		case "properties":
 			for _, property := range node.Value.(yaml.MapSlice) {
-				path := []string{}
-				properties = getJSONProperties(properties, property, path)
+				propertyName := fmt.Sprint(property.Key)
+				propertyPath := append(append([]string{}, path...), propertyName)
+
+				subschema, err := getSubschema(property.Value, b.resolver.version)
+				if err != nil {
+					return fmt.Errorf("property %s: %w", strings.Join(propertyPath, "."), err)
+				}
+
+				err = b.getJSONProperty(subschema, propertyPath, required[propertyName])
+				if err != nil {
+					return err
+				}
+			}
+		case "additionalProperties":
+			if err := b.getAdditionalProperties(node.Value, path); err != nil {
+				return err
 			}
 		}
-		//fmt.Println(node.Key, node.Value)
 	}
 
-	//fmt.Println("properties", properties)
-	return properties
+	return nil
 }
 
-func getJSONProperties(properties map[string]*Parameter, node interface{}, path []string) map[string]*Parameter {
-	switch element := node.(type) {
-	case yaml.MapItem:
-		data := element.Value.(yaml.MapSlice)
-		path = append(path, element.Key.(string))
-		paramName := strings.Join(path, ".")
-		//fmt.Println("paramName", paramName)
-		param := getRequestBodyParameter(data, paramName)
-		if val, ok := properties[paramName]; ok {
-			param.Required = val.Required
-		}
+// getJSONProperty adds parameter for a single property and walks its nested
+// properties and array items.
+func (b *RequestBody) getJSONProperty(data yaml.MapSlice, path []string, required bool) error {
+	ref := schemaRef(data)
+
+	if b.recursiveRefs && b.resolver.Walking(data) {
+		param := Parameter{Name: strings.Join(path, "."), Required: required, Ref: ref, Order: len(b.Properties)}
+		b.Properties[param.Name] = &param
+
+		return nil
+	}
 
-		//fmt.Println("param.Name", paramName)
-
-		for _, embeded := range data {
-			switch embeded.Key {
-			case "properties":
-				//fmt.Println("param.Name2", param.Name)
-				param.IsObject = true
-				getJSONProperties(properties, data, path)
-			//case "description":
-			//	fmt.Println("desc", embeded.Value)
-			case "items":
-				path[len(path)-1] = path[len(path)-1] + "[]"
-				fmt.Println("param.Name", path)
-				getJSONProperties(properties, embeded.Value, path)
+	data, release, err := b.resolve(data)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	param := getRequestBodyParameter(data, strings.Join(path, "."), b.resolver.version)
+	param.Required = required
+	param.Ref = ref
+	param.Order = len(b.Properties)
+	b.Properties[param.Name] = &param
+
+	if b.namedRefs && ref != "" {
+		return nil
+	}
+
+	if err := b.getCompositions(data, path); err != nil {
+		return err
+	}
+
+	for _, embeded := range data {
+		switch embeded.Key {
+		case "properties", "additionalProperties":
+			if param.IsObject {
+				continue
+			}
+			param.IsObject = true
+
+			if err := b.getJSONProperties(data, path); err != nil {
+				return err
+			}
+		case "items":
+			items, err := getSubschema(embeded.Value, b.resolver.version)
+			if err != nil {
+				return fmt.Errorf("items of %s: %w", param.Name, err)
+			}
+
+			param.ItemsRef = schemaRef(items)
+
+			if b.recursiveRefs && b.resolver.Walking(items) {
+				continue
+			}
+
+			items, releaseItems, err := b.resolve(items)
+			if err != nil {
+				return err
+			}
+			defer releaseItems()
+
+			param.ArrayType = getType(items, b.resolver.version)
+
+			if b.namedRefs && param.ItemsRef != "" {
+				continue
+			}
+
+			itemsPath := append([]string{}, path...)
+			itemsPath[len(itemsPath)-1] += "[]"
+
+			if isObject(items) {
+				if err := b.getCompositions(items, itemsPath); err != nil {
+					return err
+				}
+
+				err = b.getJSONProperties(items, itemsPath)
+			} else {
+				err = b.getJSONProperty(items, itemsPath, false)
+			}
+
+			if err != nil {
+				return err
 			}
 		}
+	}
+
+	return nil
+}
+
+// getAdditionalProperties records object which does not accept any
+// properties except declared ones.
+func (b *RequestBody) getAdditionalProperties(value interface{}, path []string) error {
+	objectPath := strings.Join(path, ".")
 
-		properties[param.Name] = &param
+	switch additional := value.(type) {
+	case bool:
+		if !additional {
+			b.AdditionalProperties[objectPath] = nil
+		}
 	case yaml.MapSlice:
-		//fmt.Println("222")
-		//fmt.Printf("Type: %T\n", element)
-
-		for _, embeded := range element {
-			//fmt.Println("333", embeded.Key)
-			switch embeded.Key {
-			case "type":
-				if embeded.Value.(string) != "object" && embeded.Value.(string) != "array" {
-					paramName := strings.Join(path, ".")
-					param := getRequestBodyParameter(element, paramName)
-					properties[paramName] = &param
-					break
-				}
-			case "properties":
-				for _, property := range embeded.Value.(yaml.MapSlice) {
-					//fmt.Println("property", property.Key)
-					//fmt.Println("path", path)
-					getJSONProperties(properties, property, path)
-				}
-			case "required":
-				for _, fieldName := range embeded.Value.([]interface{}) {
-					fieldPath := append(path, fieldName.(string))
-					paramName := strings.Join(fieldPath, ".")
-					if _, ok := properties[paramName]; ok {
-						properties[paramName].Required = true
-					}
+		if b.recursiveRefs && b.resolver.Walking(additional) {
+			return nil
+		}
+
+		schema, release, err := b.resolve(additional)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		// empty schema accepts any value
+		if len(schema) == 0 {
+			return nil
+		}
+
+		param := getRequestBodyParameter(schema, objectPath, b.resolver.version)
+		param.Ref = schemaRef(additional)
+		b.AdditionalProperties[objectPath] = &param
+	}
+
+	return nil
+}
+
+func getRequired(schema yaml.MapSlice) map[string]bool {
+	required := make(map[string]bool)
+
+	for _, node := range schema {
+		switch node.Key {
+		case "required":
+			if fieldNames, ok := node.Value.([]interface{}); ok {
+				for _, fieldName := range fieldNames {
+					required[fieldName.(string)] = true
 				}
 			}
 		}
 	}
 
-	return properties
+	return required
+}
+
+func getType(schema yaml.MapSlice, version string) string {
+	for _, node := range schema {
+		if node.Key == "type" {
+			schemaType, _ := getSchemaType(node.Value, version)
+
+			return schemaType
+		}
+	}
+
+	return ""
+}
+
+func isObject(schema yaml.MapSlice) bool {
+	for _, node := range schema {
+		switch node.Key {
+		case "properties", "additionalProperties":
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,58 @@
+package generate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/generate"
+)
+
+func TestGenerate_AdditionalProperties(t *testing.T) {
+	spec := `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              additionalProperties: false
+              properties:
+                variants:
+                  type: array
+                  items:
+                    type: object
+                    additionalProperties: false
+                    properties:
+                      delivery:
+                        type: object
+                        additionalProperties: false
+                        properties:
+                          time:
+                            type: integer
+                      meta:
+                        type: object
+                        additionalProperties:
+                          type: string
+                          maxLength: 5
+                      extra:
+                        type: object
+                        additionalProperties: true
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+
+	assert.Nil(t, err)
+
+	additional := validators[0].AdditionalProperties
+	assert.Len(t, additional, 4)
+	assert.Nil(t, additional[""])
+	assert.Nil(t, additional["variants[]"])
+	assert.Nil(t, additional["variants[].delivery"])
+	assert.Equal(t, "omitempty,string,max=5", additional["variants[].meta"].Rules().String())
+	assert.Contains(t, validators[0].Parameters, "variants[].meta")
+}
@@ -0,0 +1,86 @@
+package generate
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"strings"
+)
+
+const SpecResponses = "responses"
+
+// Response holds rules of a single response body. Status is a status code,
+// a range like 4XX or default. Paths are relative to the body, empty path is
+// the body itself. Response without content has no rules.
+type Response struct {
+	Status      string
+	ContentType string
+	*RequestBody
+}
+
+// getResponses walks responses of operation. Bodies of JSON media types are
+// walked, other content types are only recorded.
+func getResponses(resolver *Resolver, data yaml.MapSlice) ([]*Response, error) {
+	var responses []*Response
+
+	for _, node := range data {
+		status := fmt.Sprint(node.Key)
+
+		response, release, err := resolver.Resolve(node.Value.(yaml.MapSlice))
+		if err != nil {
+			return nil, fmt.Errorf("response %s: %w", status, err)
+		}
+
+		var content yaml.MapSlice
+		for _, item := range response {
+			if item.Key == "content" {
+				content, _ = item.Value.(yaml.MapSlice)
+			}
+		}
+
+		if len(content) == 0 {
+			responses = append(responses, &Response{Status: status, RequestBody: newRequestBody(resolver)})
+		}
+
+		for _, mediaType := range content {
+			contentType := mediaType.Key.(string)
+			body := newRequestBody(resolver)
+			// responses of recursive schemas are checked up to recursion
+			body.recursiveRefs = true
+
+			if isJSONMediaType(contentType) {
+				for _, item := range mediaType.Value.(yaml.MapSlice) {
+					if item.Key != "schema" {
+						continue
+					}
+
+					schema, err := getSubschema(item.Value, resolver.version)
+					if err == nil {
+						err = body.getJSONProperty(schema, []string{branchRoot}, true)
+					}
+
+					if err != nil {
+						release()
+
+						return nil, fmt.Errorf("response %s %s: %w", status, contentType, err)
+					}
+
+					body = body.relative()
+				}
+			}
+
+			responses = append(responses, &Response{
+				Status:      status,
+				ContentType: contentType,
+				RequestBody: body,
+			})
+		}
+
+		release()
+	}
+
+	return responses, nil
+}
+
+func isJSONMediaType(contentType string) bool {
+	return contentType == "application/json" || strings.HasSuffix(contentType, "+json")
+}
@@ -0,0 +1,140 @@
+package generate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/generate"
+)
+
+const responseSpec = `
+paths:
+  /offers:
+    get:
+      operationId: getOffers
+      responses:
+        200:
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Offer'
+            text/csv:
+              schema:
+                type: string
+        '204':
+          description: No Content
+        default:
+          $ref: '#/components/responses/Problem'
+components:
+  responses:
+    Problem:
+      description: Error
+      content:
+        application/problem+json:
+          schema:
+            type: object
+            additionalProperties: false
+            properties:
+              title:
+                type: string
+            required:
+              - title
+  schemas:
+    Offer:
+      type: object
+      properties:
+        id:
+          type: string
+      required:
+        - id
+`
+
+func TestGenerate_Responses(t *testing.T) {
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, responseSpec))
+	assert.Nil(t, err)
+
+	responses := validators[0].Responses
+	if !assert.Len(t, responses, 4) {
+		return
+	}
+
+	rules := func(response *generate.Response) map[string]string {
+		return getRules(generate.Validator{Parameters: response.Properties})
+	}
+
+	assert.Equal(t, "200", responses[0].Status)
+	assert.Equal(t, "application/json", responses[0].ContentType)
+	assert.Equal(t, map[string]string{
+		"":      "required",
+		"[].id": "required,string",
+	}, rules(responses[0]))
+
+	assert.Equal(t, "text/csv", responses[1].ContentType)
+	assert.Empty(t, responses[1].Properties)
+
+	assert.Equal(t, "204", responses[2].Status)
+	assert.Equal(t, "", responses[2].ContentType)
+
+	assert.Equal(t, "default", responses[3].Status)
+	assert.Equal(t, "application/problem+json", responses[3].ContentType)
+	assert.Equal(t, map[string]string{
+		"":      "required",
+		"title": "required,string",
+	}, rules(responses[3]))
+	assert.Contains(t, responses[3].AdditionalProperties, "")
+}
+
+func TestGenerate_Responses_Recursive(t *testing.T) {
+	spec := `
+paths:
+  /tree:
+    get:
+      operationId: getTree
+      responses:
+        200:
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Node'
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        name:
+          type: string
+        parent:
+          $ref: '#/components/schemas/Node'
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Node'
+        labels:
+          type: object
+          additionalProperties:
+            $ref: '#/components/schemas/Node'
+      required:
+        - name
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// recursive parts are checked by rules of their parent
+	assert.Equal(t, map[string]string{
+		"":         "required",
+		"name":     "required,string",
+		"parent":   "omitempty",
+		"children": "omitempty",
+		"labels":   "omitempty",
+	}, getRules(generate.Validator{Parameters: validators[0].Responses[0].Properties}))
+}
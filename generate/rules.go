@@ -2,7 +2,11 @@ package generate
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/beng90/spec2go/validate"
 )
 
 type SchemaType string
@@ -21,6 +25,8 @@ const (
 
 	FormatDate     SchemaFormat = "date"
 	FormatDateTime SchemaFormat = "date-time"
+	FormatTime     SchemaFormat = "time"
+	FormatDuration SchemaFormat = "duration"
 	FormatPassword SchemaFormat = "password"
 	FormatByte     SchemaFormat = "byte"
 	FormatBinary   SchemaFormat = "binary"
@@ -30,6 +36,10 @@ const (
 	FormatHostname SchemaFormat = "hostname"
 	FormatIPv4     SchemaFormat = "ipv4"
 	FormatIPv6     SchemaFormat = "ipv6"
+	FormatInt32    SchemaFormat = "int32"
+	FormatInt64    SchemaFormat = "int64"
+	FormatFloat    SchemaFormat = "float"
+	FormatDouble   SchemaFormat = "double"
 )
 
 var SchemaTypeToRule = map[SchemaType]RuleType{
@@ -39,14 +49,27 @@ var SchemaTypeToRule = map[SchemaType]RuleType{
 	TypeBoolean: "boolean",
 }
 
+// SchemaFormatToRule maps formats to rules, formats without rule are not
+// checked. Other formats are checked by format rule with formats registered
+// by validate.RegisterFormat.
 var SchemaFormatToRule = map[SchemaFormat]RuleFormat{
-	FormatDate:     "ISO8601",
-	FormatDateTime: "ISO8601",
+	FormatDate:     "format=date",
+	FormatDateTime: "format=date-time",
+	FormatTime:     "format=time",
+	FormatDuration: "format=duration",
+	FormatPassword: "",
+	FormatByte:     "format=byte",
+	FormatBinary:   "",
 	FormatEmail:    "email",
 	FormatUuid:     "uuid",
 	FormatUri:      "url",
-	FormatIPv4:     "ip_v4",
-	FormatIPv6:     "ip_v6",
+	FormatHostname: "format=hostname",
+	FormatIPv4:     "ipv4",
+	FormatIPv6:     "ipv6",
+	FormatInt32:    "int32",
+	FormatInt64:    "int64",
+	FormatFloat:    "float",
+	FormatDouble:   "double",
 }
 
 type Parameter struct {
@@ -58,9 +81,60 @@ type Parameter struct {
 	ArrayType   string
 	Format      string
 	Pattern     string
-	Min         *float64
-	Max         *float64
-	IsObject    bool
+	Enum        []string
+	// Min and Max are inclusive numeric limits, ExclusiveMin and ExclusiveMax
+	// are exclusive ones
+	Min          *float64
+	Max          *float64
+	ExclusiveMin *float64
+	ExclusiveMax *float64
+	MultipleOf   *float64
+	// MinLength and MaxLength are limits of string length
+	MinLength *int
+	MaxLength *int
+	// MinItems, MaxItems and UniqueItems are limits of arrays
+	MinItems    *int
+	MaxItems    *int
+	UniqueItems bool
+	// Nullable is set by nullable keyword or type list containing null
+	Nullable bool
+	IsObject bool
+	Items    *Parameter
+	// Ref and ItemsRef are names of component schemas used by parameter
+	Ref      string
+	ItemsRef string
+	// Order is a position of parameter in spec
+	Order int
+	// ErrorMessage and ErrorMessages are set by x-error-message and
+	// x-error-messages extensions
+	ErrorMessage  string
+	ErrorMessages map[string]string
+	// Tags are validator tags of x-validate extension
+	Tags []string
+	// Extensions are values of specification extensions keyed by their name
+	Extensions map[string]interface{}
+}
+
+// Parameters are keyed by path of body field or location and name of request
+// parameter.
+type Parameters map[string]*Parameter
+
+// Ordered returns parameters in order of spec.
+func (p Parameters) Ordered() []*Parameter {
+	ordered := make([]*Parameter, 0, len(p))
+	for _, param := range p {
+		ordered = append(ordered, param)
+	}
+
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].Order != ordered[j].Order {
+			return ordered[i].Order < ordered[j].Order
+		}
+
+		return ordered[i].Name < ordered[j].Name
+	})
+
+	return ordered
 }
 
 type Rules []string
@@ -76,21 +150,162 @@ func (p *Parameter) Rules() (rules Rules) {
 		rules = append(rules, "omitempty")
 	}
 
+	if p.Nullable {
+		rules = append(rules, "nullable")
+	}
+
 	if _, hasType := SchemaTypeToRule[SchemaType(p.Type)]; hasType != false {
 		rules = append(rules, fmt.Sprintf(`%s`, string(SchemaTypeToRule[SchemaType(p.Type)])))
 	}
 
 	if format, hasType := SchemaFormatToRule[SchemaFormat(p.Format)]; hasType != false {
-		rules = append(rules, fmt.Sprintf(`%s`, format))
+		if format != "" {
+			rules = append(rules, fmt.Sprintf(`%s`, format))
+		}
+	} else if p.Format != "" {
+		rules = append(rules, "format="+p.Format)
+	}
+
+	if len(p.Enum) > 0 {
+		rules = append(rules, OneOfRule(p.Enum))
+	}
+
+	if p.MinLength != nil {
+		rules = append(rules, fmt.Sprintf(`min=%d`, *p.MinLength))
+	}
+
+	if p.MaxLength != nil {
+		rules = append(rules, fmt.Sprintf(`max=%d`, *p.MaxLength))
 	}
 
 	if p.Min != nil {
-		rules = append(rules, fmt.Sprintf(`min=%.f`, *p.Min))
+		rules = append(rules, "min="+strconv.FormatFloat(*p.Min, 'f', -1, 64))
 	}
 
 	if p.Max != nil {
-		rules = append(rules, fmt.Sprintf(`max=%.f`, *p.Max))
+		rules = append(rules, "max="+strconv.FormatFloat(*p.Max, 'f', -1, 64))
+	}
+
+	if p.ExclusiveMin != nil {
+		rules = append(rules, "gt="+strconv.FormatFloat(*p.ExclusiveMin, 'f', -1, 64))
+	}
+
+	if p.ExclusiveMax != nil {
+		rules = append(rules, "lt="+strconv.FormatFloat(*p.ExclusiveMax, 'f', -1, 64))
+	}
+
+	if p.MultipleOf != nil {
+		rules = append(rules, "multipleOf="+strconv.FormatFloat(*p.MultipleOf, 'f', -1, 64))
+	}
+
+	if p.MinItems != nil {
+		rules = append(rules, fmt.Sprintf(`min=%d`, *p.MinItems))
+	}
+
+	if p.MaxItems != nil {
+		rules = append(rules, fmt.Sprintf(`max=%d`, *p.MaxItems))
+	}
+
+	if p.UniqueItems {
+		rules = append(rules, "uniqueItems")
 	}
 
+	rules = append(rules, p.Tags...)
+
 	return
 }
+
+// Messages returns spec-authored error messages of parameters keyed by their
+// name.
+func (p Parameters) Messages() map[string]map[string]string {
+	var messages map[string]map[string]string
+
+	for _, param := range p {
+		paramMessages := param.Messages()
+		if paramMessages == nil {
+			continue
+		}
+
+		if messages == nil {
+			messages = make(map[string]map[string]string)
+		}
+		messages[param.Name] = paramMessages
+	}
+
+	return messages
+}
+
+// keywordRules maps schema keywords used in x-error-messages to rules
+var keywordRules = map[string]string{
+	"pattern":          "regexp",
+	"minimum":          "min",
+	"minLength":        "min",
+	"maximum":          "max",
+	"maxLength":        "max",
+	"minItems":         "min",
+	"maxItems":         "max",
+	"exclusiveMinimum": "gt",
+	"exclusiveMaximum": "lt",
+	"enum":             "oneof",
+	"const":            "oneof",
+}
+
+// Messages returns spec-authored error messages keyed by rule. Message under
+// empty key is used for every rule without its own message.
+func (p *Parameter) Messages() map[string]string {
+	if p.ErrorMessage == "" && len(p.ErrorMessages) == 0 {
+		return nil
+	}
+
+	messages := make(map[string]string)
+	if p.ErrorMessage != "" {
+		messages[""] = p.ErrorMessage
+	}
+
+	for keyword, message := range p.ErrorMessages {
+		rule := keyword
+		switch keyword {
+		case "type":
+			rule = string(SchemaTypeToRule[SchemaType(p.Type)])
+		case "format":
+			format, ok := SchemaFormatToRule[SchemaFormat(p.Format)]
+			if !ok && p.Format != "" {
+				format = RuleFormat(validate.RuleFormat)
+			}
+
+			// failed rule is named without parameter, e.g. format of format=date
+			rule = strings.SplitN(string(format), "=", 2)[0]
+		default:
+			if keywordRule, ok := keywordRules[keyword]; ok {
+				rule = keywordRule
+			}
+		}
+
+		if rule != "" {
+			messages[rule] = message
+		}
+	}
+
+	return messages
+}
+
+// oneOfEscaper escapes characters used as separators in validation tags,
+// validator replaces them back before the rule is checked.
+var oneOfEscaper = strings.NewReplacer(",", "0x2C", "|", "0x7C")
+
+// OneOfRule returns oneof rule for enum values. Values containing spaces or
+// quotes are wrapped in single quotes.
+func OneOfRule(values []string) string {
+	encoded := make([]string, 0, len(values))
+
+	for _, value := range values {
+		value = oneOfEscaper.Replace(value)
+		if value == "" || strings.ContainsAny(value, " '") {
+			value = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+		}
+
+		encoded = append(encoded, value)
+	}
+
+	return "oneof=" + strings.Join(encoded, " ")
+}
@@ -0,0 +1,506 @@
+package generate_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/generate"
+	"github.com/beng90/spec2go/validate"
+)
+
+func TestOneOfRule(t *testing.T) {
+	assert.Equal(t, "oneof=active inactive", generate.OneOfRule([]string{"active", "inactive"}))
+	assert.Equal(t, "oneof='on hold' a0x2Cb a0x7Cb 'it''s' ''", generate.OneOfRule([]string{"on hold", "a,b", "a|b", "it's", ""}))
+}
+
+func TestGenerate_Enum(t *testing.T) {
+	spec := `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                status:
+                  type: string
+                  enum:
+                    - active
+                    - on hold
+                size:
+                  type: integer
+                  enum: [10, 20]
+                tags:
+                  type: array
+                  items:
+                    type: string
+                    enum: [a, 'b,c']
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"status": "omitempty,string,oneof=active 'on hold'",
+		"size":   "omitempty,integer,oneof=10 20",
+		"tags":   "omitempty",
+		"tags[]": "omitempty,string,oneof=a b0x2Cc",
+	}, getRules(validators[0]))
+}
+
+func TestGenerate_Nullable(t *testing.T) {
+	spec := `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                brand:
+                  type: string
+                  nullable: true
+                sku:
+                  type: string
+                  nullable: false
+                size:
+                  type: [integer, 'null']
+              required:
+                - brand
+`
+
+	testData := []struct {
+		version string
+		want    map[string]string
+	}{
+		// type list is not allowed in 3.0
+		{"3.0.3", map[string]string{
+			"brand": "required,nullable,string",
+			"sku":   "omitempty,string",
+			"size":  "omitempty",
+		}},
+		// null is a type in 3.1, nullable keyword is gone
+		{"3.1.0", map[string]string{
+			"brand": "required,string",
+			"sku":   "omitempty,string",
+			"size":  "omitempty,nullable,integer",
+		}},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.version, func(t *testing.T) {
+			validators := []generate.Validator{}
+			err := generate.Generate(&validators, getSpec(t, "openapi: "+tt.version+spec))
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, getRules(validators[0]))
+		})
+	}
+}
+
+func TestGenerate_BooleanSchemas(t *testing.T) {
+	spec := `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                anything: true
+                nothing: false
+                tags:
+                  type: array
+                  items: false
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, "openapi: 3.1.0"+spec))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"anything": "omitempty",
+		"nothing":  "omitempty",
+		"tags":     "omitempty",
+		"tags[]":   "omitempty",
+	}, getRules(validators[0]))
+
+	var fields []string
+	for _, composition := range validators[0].Compositions {
+		assert.Equal(t, generate.SpecNot, composition.Keyword)
+		fields = append(fields, composition.Field)
+	}
+	assert.ElementsMatch(t, []string{"nothing", "tags[]"}, fields)
+
+	// boolean schemas are not allowed in 3.0
+	err = generate.Generate(&validators, getSpec(t, "openapi: 3.0.3"+spec))
+
+	assert.True(t, errors.Is(err, generate.ErrInvalidSchema))
+	assert.EqualError(t, err, "POST /offers: property anything: invalid schema: true")
+}
+
+func TestGenerate_IntegerFormat(t *testing.T) {
+	spec := `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                quantity:
+                  type: integer
+                  format: int32
+                id:
+                  type: integer
+                  format: int64
+                  minimum: 1
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"quantity": "omitempty,integer,int32",
+		"id":       "omitempty,integer,int64,min=1",
+	}, getRules(validators[0]))
+}
+
+func TestGenerate_ArrayItems(t *testing.T) {
+	spec := `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      parameters:
+        - name: ids
+          in: query
+          schema:
+            type: array
+            maxItems: 10
+            uniqueItems: true
+            items:
+              type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                variants:
+                  type: array
+                  minItems: 1
+                  maxItems: 5
+                  items:
+                    type: object
+                    properties:
+                      tags:
+                        type: array
+                        uniqueItems: true
+                        items:
+                          type: string
+              required:
+                - variants
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"variants":          "required,min=1,max=5",
+		"variants[].tags":   "omitempty,uniqueItems",
+		"variants[].tags[]": "omitempty,string",
+	}, getRules(validators[0]))
+	assert.Equal(t, "omitempty,max=10,uniqueItems", validators[0].RequestParameters.Ordered()[0].Rules().String())
+}
+
+func TestGenerate_Bounds(t *testing.T) {
+	spec := `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                  minLength: 2
+                  maxLength: 64
+                price:
+                  type: number
+                  minimum: 0.01
+                  maximum: 999.99
+                  multipleOf: 0.01
+                discount:
+                  type: number
+                  minimum: 0
+                  exclusiveMinimum: true
+                  maximum: 0.5
+                  exclusiveMaximum: true
+                quantity:
+                  type: integer
+                  minimum: 1
+                  exclusiveMinimum: false
+                  multipleOf: 5
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"name":     "omitempty,string,min=2,max=64",
+		"price":    "omitempty,numeric,min=0.01,max=999.99,multipleOf=0.01",
+		"discount": "omitempty,numeric,gt=0,lt=0.5",
+		"quantity": "omitempty,integer,min=1,multipleOf=5",
+	}, getRules(validators[0]))
+}
+
+func TestGenerate_Formats(t *testing.T) {
+	spec := `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                validFrom:
+                  type: string
+                  format: date
+                createdAt:
+                  type: string
+                  format: date-time
+                password:
+                  type: string
+                  format: password
+                image:
+                  type: string
+                  format: byte
+                host:
+                  type: string
+                  format: hostname
+                weight:
+                  type: number
+                  format: float
+                sku:
+                  type: string
+                  format: sku
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"validFrom": "omitempty,string,format=date",
+		"createdAt": "omitempty,string,format=date-time",
+		"password":  "omitempty,string",
+		"image":     "omitempty,string,format=byte",
+		"host":      "omitempty,string,format=hostname",
+		"weight":    "omitempty,numeric,float",
+		"sku":       "omitempty,string,format=sku",
+	}, getRules(validators[0]))
+}
+
+func TestGenerate_ValidateExtension(t *testing.T) {
+	spec := `
+paths:
+  /offers/{id}:
+    post:
+      operationId: addOffer
+      parameters:
+        - name: country
+          in: query
+          schema:
+            type: string
+            x-validate: iso3166_1_alpha2
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                  maxLength: 255
+                  x-validate: notblank
+                tags:
+                  type: array
+                  items:
+                    type: string
+                    x-validate: [notblank, "excludesall=!#"]
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"name":   "omitempty,string,max=255,notblank",
+		"tags":   "omitempty",
+		"tags[]": "omitempty,string,notblank,excludesall=!#",
+	}, getRules(validators[0]))
+	assert.Equal(t, "omitempty,string,iso3166_1_alpha2", validators[0].RequestParameters.Ordered()[0].Rules().String())
+
+	unknown := strings.Replace(spec, "x-validate: notblank", "x-validate: notblank,sku,skus", 1)
+	err = generate.GenerateWith(&validators, getSpec(t, unknown), generate.Options{Tags: generate.DefaultTags("sku")})
+
+	assert.True(t, errors.Is(err, generate.ErrUnknownTag))
+	assert.EqualError(t, err, `POST /offers/{id}: requestBody name: unknown validator tag "skus"`)
+
+	validators = []generate.Validator{}
+	extension := strings.ReplaceAll(spec, "x-validate:", "x-rules:")
+	err = generate.GenerateWith(&validators, getSpec(t, extension), generate.Options{ValidateExtension: "x-rules"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "omitempty,string,max=255,notblank", getRules(validators[0])["name"])
+}
+
+func TestDefaultTags(t *testing.T) {
+	tags := generate.DefaultTags("sku")
+
+	for _, name := range []string{"required", "dive", "iscolor", "iso3166_1_alpha2", "notblank", "uniqueItems", "sku"} {
+		assert.True(t, tags(name), name)
+	}
+
+	assert.False(t, tags("skus"))
+}
+
+func TestGenerate_ErrorMessages(t *testing.T) {
+	spec := `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                categoryId:
+                  type: string
+                  pattern: '^\d+_\d+$'
+                  x-error-message: Category id is invalid
+                  x-error-messages:
+                    pattern: Category id must look like 435_12
+                    minLength: Category id is too short
+                    type: Category id must be a string
+                name:
+                  type: string
+                createdAt:
+                  type: string
+                  format: date
+                  x-error-messages:
+                    format: Creation date must look like 2021-05-01
+                email:
+                  type: string
+                  format: email
+                  x-error-messages:
+                    format: Email is invalid
+                sku:
+                  type: string
+                  format: sku
+                  x-error-messages:
+                    format: SKU is invalid
+                tags:
+                  type: array
+                  minItems: 1
+                  maxItems: 10
+                  items:
+                    type: string
+                  x-error-messages:
+                    minItems: At least one tag is required
+                    maxItems: At most 10 tags are allowed
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"categoryId": {
+			"":       "Category id is invalid",
+			"regexp": "Category id must look like 435_12",
+			"min":    "Category id is too short",
+			"string": "Category id must be a string",
+		},
+		"createdAt": {"format": "Creation date must look like 2021-05-01"},
+		"email":     {"email": "Email is invalid"},
+		"sku":       {"format": "SKU is invalid"},
+		"tags":      {"min": "At least one tag is required", "max": "At most 10 tags are allowed"},
+	}, validators[0].Parameters.Messages())
+}
+
+func TestGenerate_InvalidPattern(t *testing.T) {
+	spec := `
+paths:
+  /offers/{id}:
+    post:
+      operationId: addOffer
+      parameters:
+        - name: id
+          in: path
+          schema:
+            type: string
+            pattern: '^[a-z'
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                  pattern: '^(a$'
+`
+
+	validators := []generate.Validator{}
+	err := generate.Generate(&validators, getSpec(t, spec))
+
+	assert.True(t, errors.Is(err, generate.ErrInvalidPattern))
+	assert.Contains(t, err.Error(), "POST /offers/{id}: requestBody name: invalid pattern")
+}
+
+func TestSchemaFormatToRule_Registered(t *testing.T) {
+	v := validator.New()
+	validate.RegisterCustomValidations(v)
+
+	for format, rule := range generate.SchemaFormatToRule {
+		if rule == "" {
+			continue
+		}
+
+		// undefined rule panics
+		assert.NotPanics(t, func() {
+			_ = v.Var("", "omitempty,"+string(rule))
+		}, format)
+	}
+}
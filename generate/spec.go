@@ -0,0 +1,133 @@
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	SpecOpenAPI = "openapi"
+	SpecSwagger = "swagger"
+
+	Version30 = "3.0"
+	Version31 = "3.1"
+)
+
+var ErrUnsupportedVersion = errors.New("unsupported OpenAPI version")
+
+// ParseSpec decodes OpenAPI document in YAML or JSON format. Order of keys is
+// kept in both cases.
+func ParseSpec(data []byte) (yaml.MapSlice, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		spec := yaml.MapSlice{}
+		if err := yaml.Unmarshal(data, &spec); err != nil {
+			return nil, err
+		}
+
+		return spec, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+
+	value, err := decodeJSON(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("invalid JSON: unexpected data after document")
+	}
+
+	return value.(yaml.MapSlice), nil
+}
+
+// decodeJSON decodes next JSON value into types used by yaml decoder.
+func decodeJSON(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	switch v := token.(type) {
+	case json.Delim:
+		switch v {
+		case '{':
+			object := yaml.MapSlice{}
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, fmt.Errorf("invalid JSON: %w", err)
+				}
+
+				value, err := decodeJSON(decoder)
+				if err != nil {
+					return nil, err
+				}
+
+				object = append(object, yaml.MapItem{Key: key, Value: value})
+			}
+
+			_, err = decoder.Token()
+
+			return object, err
+		case '[':
+			array := []interface{}{}
+			for decoder.More() {
+				value, err := decodeJSON(decoder)
+				if err != nil {
+					return nil, err
+				}
+
+				array = append(array, value)
+			}
+
+			_, err = decoder.Token()
+
+			return array, err
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil && int64(int(i)) == i {
+			return int(i), nil
+		}
+
+		return v.Float64()
+	}
+
+	return token, nil
+}
+
+// GetVersion returns major and minor OpenAPI version of spec. Document
+// without version is treated as OpenAPI 3.0.
+func GetVersion(spec yaml.MapSlice) (string, error) {
+	for _, node := range spec {
+		switch node.Key {
+		case SpecSwagger:
+			return "", fmt.Errorf("%w: swagger %v", ErrUnsupportedVersion, node.Value)
+		case SpecOpenAPI:
+			version := fmt.Sprint(node.Value)
+			// unquoted version is decoded as a number
+			if v, ok := node.Value.(float64); ok {
+				version = strconv.FormatFloat(v, 'f', 1, 64)
+			}
+
+			switch {
+			case version == Version30 || strings.HasPrefix(version, Version30+"."):
+				return Version30, nil
+			case version == Version31 || strings.HasPrefix(version, Version31+"."):
+				return Version31, nil
+			}
+
+			return "", fmt.Errorf("%w: %s", ErrUnsupportedVersion, version)
+		}
+	}
+
+	return Version30, nil
+}
@@ -0,0 +1,107 @@
+package generate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+
+	"github.com/beng90/spec2go/generate"
+)
+
+const jsonSpec = `{
+  "openapi": "3.1.0",
+  "paths": {
+    "/offers": {
+      "post": {
+        "operationId": "addOffer",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "properties": {
+                  "name": {"type": ["string", "null"], "examples": ["foo"]},
+                  "kind": {"const": "offer"},
+                  "price": {"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 99.5},
+                  "count": {"type": "integer", "minimum": 1},
+                  "value": {"type": ["string", "integer"]}
+                },
+                "required": ["kind"]
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func TestParseSpec(t *testing.T) {
+	spec, err := generate.ParseSpec([]byte(`{"openapi": "3.1.0", "info": {"b": 1, "a": [1.5, true, null, "x"]}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, yaml.MapSlice{
+		{Key: "openapi", Value: "3.1.0"},
+		{Key: "info", Value: yaml.MapSlice{
+			{Key: "b", Value: 1},
+			{Key: "a", Value: []interface{}{1.5, true, nil, "x"}},
+		}},
+	}, spec)
+
+	_, err = generate.ParseSpec([]byte(`{"openapi": "3.1.0"`))
+	assert.Error(t, err)
+
+	_, err = generate.ParseSpec([]byte(`{"openapi": "3.1.0"} {}`))
+	assert.Error(t, err)
+
+	spec, err = generate.ParseSpec([]byte("openapi: 3.0.3\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, yaml.MapSlice{{Key: "openapi", Value: "3.0.3"}}, spec)
+}
+
+func TestGetVersion(t *testing.T) {
+	testData := []struct {
+		spec    string
+		version string
+		err     error
+	}{
+		{"openapi: 3.0.3", generate.Version30, nil},
+		{"openapi: 3.0", generate.Version30, nil},
+		{"openapi: 3.1.0", generate.Version31, nil},
+		{"paths: {}", generate.Version30, nil},
+		{"openapi: 3.2.0", "", generate.ErrUnsupportedVersion},
+		{"swagger: '2.0'", "", generate.ErrUnsupportedVersion},
+	}
+
+	for _, tt := range testData {
+		version, err := generate.GetVersion(getSpec(t, tt.spec))
+		assert.Equal(t, tt.version, version, tt.spec)
+		assert.True(t, errors.Is(err, tt.err), tt.spec)
+	}
+
+	var validators []generate.Validator
+	err := generate.Generate(&validators, getSpec(t, "swagger: '2.0'"))
+	assert.True(t, errors.Is(err, generate.ErrUnsupportedVersion))
+}
+
+func TestGenerate_OpenAPI31(t *testing.T) {
+	spec, err := generate.ParseSpec([]byte(jsonSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var validators []generate.Validator
+	if err := generate.Generate(&validators, spec); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, map[string]string{
+		"name":  "omitempty,nullable,string",
+		"kind":  "required,oneof=offer",
+		"price": "omitempty,numeric,gt=0,lt=99.5",
+		"count": "omitempty,integer,min=1",
+		"value": "omitempty",
+	}, getRules(validators[0]))
+	assert.True(t, validators[0].Parameters["name"].Nullable)
+}
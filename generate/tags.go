@@ -0,0 +1,129 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/beng90/spec2go/validate"
+)
+
+// ErrUnknownTag is returned for validator tag of x-validate extension which is
+// not known by Options.Tags, so it is reported by generator instead of
+// panicking at runtime.
+var ErrUnknownTag = errors.New("unknown validator tag")
+
+// TagFunc reports whether validator tag is registered in validator used at
+// runtime.
+type TagFunc func(name string) bool
+
+// bakedInTags are tags of github.com/go-playground/validator/v10 including
+// its aliases and tags changing validation, like omitempty and dive.
+var bakedInTags = []string{
+	"omitempty", "dive", "keys", "endkeys", "structonly", "nostructlevel", "isdefault",
+	"required", "required_if", "required_unless", "required_with", "required_with_all",
+	"required_without", "required_without_all", "excluded_with", "excluded_with_all",
+	"excluded_without", "excluded_without_all",
+	"len", "min", "max", "eq", "ne", "lt", "lte", "gt", "gte",
+	"eqfield", "eqcsfield", "necsfield", "gtcsfield", "gtecsfield", "ltcsfield", "ltecsfield",
+	"nefield", "gtefield", "gtfield", "ltefield", "ltfield", "fieldcontains", "fieldexcludes",
+	"alpha", "alphanum", "alphaunicode", "alphanumunicode", "boolean", "numeric", "number",
+	"hexadecimal", "hexcolor", "rgb", "rgba", "hsl", "hsla", "iscolor", "e164", "email",
+	"url", "uri", "urn_rfc2141", "file", "base64", "base64url",
+	"contains", "containsany", "containsrune", "excludes", "excludesall", "excludesrune",
+	"startswith", "endswith", "startsnotwith", "endsnotwith",
+	"isbn", "isbn10", "isbn13", "eth_addr", "btc_addr", "btc_addr_bech32",
+	"uuid", "uuid3", "uuid4", "uuid5", "uuid_rfc4122", "uuid3_rfc4122", "uuid4_rfc4122", "uuid5_rfc4122",
+	"ascii", "printascii", "multibyte", "datauri", "latitude", "longitude", "ssn",
+	"ipv4", "ipv6", "ip", "cidrv4", "cidrv6", "cidr",
+	"tcp4_addr", "tcp6_addr", "tcp_addr", "udp4_addr", "udp6_addr", "udp_addr",
+	"ip4_addr", "ip6_addr", "ip_addr", "unix_addr", "mac",
+	"hostname", "hostname_rfc1123", "fqdn", "unique", "oneof", "html", "html_encoded", "url_encoded",
+	"dir", "json", "jwt", "hostname_port", "lowercase", "uppercase", "datetime", "timezone",
+	"country_code", "iso3166_1_alpha2", "iso3166_1_alpha3", "iso3166_1_alpha_numeric", "iso3166_2",
+	"iso4217", "iso4217_numeric", "bcp47_language_tag",
+	"postcode_iso3166_alpha2", "postcode_iso3166_alpha2_field", "bic",
+}
+
+// DefaultTags returns TagFunc of tags built in validator, rules registered by
+// validate.RegisterCustomValidations and given custom tags, e.g. names of
+// -validate-tags flag.
+func DefaultTags(custom ...string) TagFunc {
+	known := make(map[string]bool)
+	for _, tags := range [][]string{bakedInTags, validate.CustomValidations(), custom} {
+		for _, tag := range tags {
+			known[tag] = true
+		}
+	}
+
+	return func(name string) bool {
+		return known[name]
+	}
+}
+
+// setTags sets tags of extension to all parameters of validators and reports
+// tags which are not known by tags function.
+func setTags(validators []Validator, extension string, tags TagFunc) error {
+	for i := range validators {
+		validator := &validators[i]
+
+		err := eachParameter(validator, func(location string, param *Parameter) error {
+			value, ok := param.Extensions[extension]
+			if !ok {
+				return nil
+			}
+
+			param.Tags = getValidateTags(value)
+
+			for _, tag := range param.Tags {
+				for _, name := range tagNames(tag) {
+					if !tags(name) {
+						return fmt.Errorf("%s: %w %q", location, ErrUnknownTag, name)
+					}
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", validator.Method, validator.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// getValidateTags returns tags of extension, given as comma separated string
+// or a list.
+func getValidateTags(value interface{}) (tags []string) {
+	switch v := value.(type) {
+	case string:
+		tags = append(tags, v)
+	case []interface{}:
+		for _, tag := range v {
+			tags = append(tags, fmt.Sprint(tag))
+		}
+	}
+
+	var split []string
+	for _, tag := range tags {
+		for _, t := range strings.Split(tag, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				split = append(split, t)
+			}
+		}
+	}
+
+	return split
+}
+
+// tagNames returns names of validations of tag without their parameters,
+// e.g. oneof and max of "oneof=a b|max=2".
+func tagNames(tag string) []string {
+	var names []string
+	for _, alternative := range strings.Split(tag, "|") {
+		names = append(names, strings.SplitN(alternative, "=", 2)[0])
+	}
+
+	return names
+}
@@ -1,46 +1,207 @@
 package main
 
 import (
-	"github.com/beng90/spec2go/generate"
-	"gopkg.in/yaml.v2"
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/fs"
 	"io/ioutil"
-	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"text/template"
+
+	"github.com/beng90/spec2go/generate"
+)
+
+const (
+	validatorsTemplate = "validators.tpl"
+	modelsTemplate     = "models.tpl"
+
+	patternEngineRE2  = "re2"
+	patternEngineECMA = "ecma"
 )
 
+//go:embed validators.tpl models.tpl
+var templates embed.FS
+
+// templateData is passed to every template.
+type templateData struct {
+	Package string
+	*generate.Models
+}
+
+type options struct {
+	spec       string
+	out        string
+	pkg        string
+	template   string
+	operations []string
+	generator  generate.Options
+}
+
 func main() {
-	file, err := ioutil.ReadFile("openapi.yml")
+	if err := run(os.Args[1:], os.Stdin); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+
+		fmt.Fprintln(os.Stderr, "spec2go:", err)
+
+		os.Exit(1)
+	}
+}
+
+func parseFlags(args []string) (*options, error) {
+	opts := &options{}
+	var operations, patternEngine, tags string
+
+	flags := flag.NewFlagSet("spec2go", flag.ContinueOnError)
+	flags.StringVar(&opts.spec, "spec", "openapi.yml", "path of OpenAPI spec in YAML or JSON format, \"-\" reads it from stdin")
+	flags.StringVar(&opts.out, "out", "openapi", "output directory of validators.go and models.go")
+	flags.StringVar(&opts.pkg, "package", "", "package name of generated code, defaults to name of output directory")
+	flags.StringVar(&opts.template, "template", "", "directory with validators.tpl and models.tpl replacing built-in templates")
+	flags.StringVar(&operations, "operations", "", "comma separated operationIds to generate, all operations by default")
+	flags.StringVar(&patternEngine, "pattern-engine", patternEngineRE2, "engine matching patterns at runtime: \"re2\" translates them from ECMA-262, \"ecma\" keeps them for validate.PatternEngine")
+	flags.StringVar(&opts.generator.ValidateExtension, "validate-extension", generate.SpecValidate, "schema extension with validator tags appended to generated rules")
+	flags.StringVar(&tags, "validate-tags", "", "comma separated names of custom validations registered at runtime, used in validate extension")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if flags.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+
+	switch patternEngine {
+	case patternEngineRE2:
+		opts.generator.Pattern = generate.TranslatePattern
+	case patternEngineECMA:
+		opts.generator.Pattern = generate.ECMAPattern
+	default:
+		return nil, fmt.Errorf("unknown pattern engine: %s", patternEngine)
+	}
+
+	var custom []string
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			custom = append(custom, tag)
+		}
+	}
+	opts.generator.Tags = generate.DefaultTags(custom...)
+
+	for _, operation := range strings.Split(operations, ",") {
+		if operation = strings.TrimSpace(operation); operation != "" {
+			opts.operations = append(opts.operations, operation)
+		}
+	}
+
+	if opts.pkg == "" {
+		out, err := filepath.Abs(opts.out)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.pkg = filepath.Base(out)
+	}
+
+	return opts, nil
+}
+
+func run(args []string, stdin io.Reader) error {
+	opts, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+
+	file, err := readSpec(opts.spec, stdin)
+	if err != nil {
+		return err
+	}
+
+	data, err := generate.ParseSpec(file)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("parse spec %s: %w", opts.spec, err)
 	}
 
 	validators := []generate.Validator{}
-	data := yaml.MapSlice{}
-	yaml.Unmarshal(file, &data)
+	if err := generate.GenerateWith(&validators, data, opts.generator); err != nil {
+		return fmt.Errorf("generate: %w", err)
+	}
+
+	validators, err = generate.FilterOperations(validators, opts.operations)
+	if err != nil {
+		return err
+	}
 
-	generate.Generate(&validators, data)
+	models, err := generate.GenerateModels(validators, data)
+	if err != nil {
+		return fmt.Errorf("generate models: %w", err)
+	}
 
-	//fmt.Println("validators", validators)
+	var templateFS fs.FS = templates
+	if opts.template != "" {
+		templateFS = os.DirFS(opts.template)
+	}
 
-	templateFiles := []string{
-		"validators.tpl",
+	if err := os.MkdirAll(opts.out, 0755); err != nil {
+		return err
 	}
 
-	t := template.Must(template.New("validators.tpl").ParseFiles(templateFiles...))
+	for _, name := range []string{validatorsTemplate, modelsTemplate} {
+		output := filepath.Join(opts.out, strings.TrimSuffix(name, ".tpl")+".go")
 
-	f, err := os.Create("openapi/validators.go")
+		if err := render(templateFS, name, output, templateData{opts.pkg, models}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readSpec(path string, stdin io.Reader) ([]byte, error) {
+	if path == "-" {
+		file, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("read spec from stdin: %w", err)
+		}
+
+		return file, nil
+	}
+
+	file, err := ioutil.ReadFile(path)
 	if err != nil {
-		log.Println("create file: ", err)
-		return
+		return nil, fmt.Errorf("read spec: %w", err)
 	}
 
-	err = t.Execute(f, validators)
+	return file, nil
+}
+
+// render executes template and writes gofmt-ed result to output file.
+func render(templateFS fs.FS, name, output string, data templateData) error {
+	t, err := template.New(name).ParseFS(templateFS, name)
 	if err != nil {
-		log.Println("executing template:", err)
+		return fmt.Errorf("parse template: %w", err)
+	}
 
-		os.Exit(1)
+	var buffer bytes.Buffer
+	if err := t.Execute(&buffer, data); err != nil {
+		return fmt.Errorf("execute template %s: %w", name, err)
+	}
+
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return fmt.Errorf("format %s: %w", output, err)
+	}
+
+	if err := ioutil.WriteFile(output, source, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", output, err)
 	}
 
-	_ = f.Close()
+	return nil
 }
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/generate"
+)
+
+const testSpec = `
+paths:
+  /offers:
+    post:
+      operationId: addOffer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+  /tags:
+    post:
+      operationId: addTag
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+`
+
+func TestRun(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "api")
+
+	err := run([]string{"-spec", "-", "-out", out, "-operations", "addTag"}, strings.NewReader(testSpec))
+	assert.NoError(t, err)
+
+	validators, _ := ioutil.ReadFile(filepath.Join(out, "validators.go"))
+	assert.True(t, strings.HasPrefix(string(validators), "package api\n"))
+	assert.Contains(t, string(validators), "func AddTagValidate(")
+	assert.NotContains(t, string(validators), "AddOffer")
+
+	models, _ := ioutil.ReadFile(filepath.Join(out, "models.go"))
+	assert.Contains(t, string(models), "type AddTagRequest struct {\n\tName *string `json:\"name,omitempty\"`\n}")
+}
+
+func TestRun_Errors(t *testing.T) {
+	out := t.TempDir()
+
+	err := run([]string{"-spec", "-", "-out", out}, strings.NewReader("paths: ["))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "parse spec -")
+
+	err = run([]string{"-spec", "-", "-out", out, "-operations", "foo"}, strings.NewReader(testSpec))
+	assert.True(t, errors.Is(err, generate.ErrUnknownOperation))
+
+	err = run([]string{"-spec", filepath.Join(out, "missing.yml"), "-out", out}, strings.NewReader(""))
+	assert.Error(t, err)
+
+	err = run([]string{"-spec", "-", "-out", out, "-template", out}, strings.NewReader(testSpec))
+	assert.Error(t, err)
+
+	err = run([]string{"-spec", "-", "-out", out, "-pattern-engine", "pcre"}, strings.NewReader(testSpec))
+	assert.EqualError(t, err, "unknown pattern engine: pcre")
+
+	err = run([]string{"-spec", "-", "-out", out}, strings.NewReader(strings.Replace(testSpec, "type: string", "type: string\n                  pattern: '(?=a)'", 1)))
+	assert.True(t, errors.Is(err, generate.ErrUnsupportedPattern))
+
+	err = run([]string{"-spec", "-", "-out", out, "-package", "api", "-pattern-engine", "ecma"}, strings.NewReader(strings.Replace(testSpec, "type: string", "type: string\n                  pattern: '(?=a)'", 1)))
+	assert.NoError(t, err)
+}
+
+func TestRun_ValidateTags(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "api")
+	spec := strings.Replace(testSpec, "type: string", "type: string\n                  x-validate: notblank,sku", 1)
+
+	err := run([]string{"-spec", "-", "-out", out}, strings.NewReader(spec))
+	assert.True(t, errors.Is(err, generate.ErrUnknownTag))
+
+	err = run([]string{"-spec", "-", "-out", out, "-validate-tags", "sku"}, strings.NewReader(spec))
+	assert.NoError(t, err)
+
+	validators, _ := ioutil.ReadFile(filepath.Join(out, "validators.go"))
+	assert.Contains(t, string(validators), `Rule: "omitempty,string,notblank,sku"`)
+
+	spec = strings.Replace(testSpec, "type: string", "type: string\n                  x-rules: [notblank]", 1)
+	err = run([]string{"-spec", "-", "-out", out, "-validate-extension", "x-rules"}, strings.NewReader(spec))
+	assert.NoError(t, err)
+
+	validators, _ = ioutil.ReadFile(filepath.Join(out, "validators.go"))
+	assert.Contains(t, string(validators), `Rule: "omitempty,string,notblank"`)
+}
+
+func TestRun_RootComposition(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "api")
+	spec := `
+paths:
+  /pets:
+    post:
+      operationId: addPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              oneOf:
+                - $ref: '#/components/schemas/Cat'
+                - $ref: '#/components/schemas/Dog'
+              discriminator:
+                propertyName: kind
+components:
+  schemas:
+    Cat:
+      type: object
+      properties:
+        kind:
+          type: string
+    Dog:
+      type: object
+      properties:
+        kind:
+          type: string
+`
+
+	err := run([]string{"-spec", "-", "-out", out}, strings.NewReader(spec))
+	assert.NoError(t, err)
+
+	validators, _ := ioutil.ReadFile(filepath.Join(out, "validators.go"))
+	assert.Contains(t, string(validators), "var AddPetValidateSchema = validate.NewSchema(\n\tnil,")
+	assert.Contains(t, string(validators), "func AddPetValidate(")
+	assert.Contains(t, string(validators), `{Method: "POST", Path: "/pets", Validate: AddPetValidate}`)
+}
+
+// TestRun_Example keeps example validators in sync with generator, they are
+// regenerated with go generate ./example.
+func TestRun_Example(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "validators")
+
+	err := run([]string{"-spec", "openapi.yml", "-out", out}, nil)
+	assert.NoError(t, err)
+
+	for _, name := range []string{"validators.go", "models.go"} {
+		generated, _ := ioutil.ReadFile(filepath.Join(out, name))
+		example, _ := ioutil.ReadFile(filepath.Join("example", "validators", name))
+
+		assert.Equal(t, string(generated), string(example), "example/validators/%s is out of date, run go generate ./example", name)
+	}
+}
+
+// buildGenerated generates spec into package inside module and builds it.
+func buildGenerated(t *testing.T, spec string) string {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	out, err := ioutil.TempDir(".", "_generated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(out)
+
+	if err := run([]string{"-spec", "-", "-out", out, "-package", "generated"}, strings.NewReader(spec)); err != nil {
+		t.Fatal(err)
+	}
+
+	build, err := exec.Command(goBin, "build", "./"+filepath.Base(out)).CombinedOutput()
+	if err != nil {
+		t.Fatalf("build generated code: %v\n%s", err, build)
+	}
+
+	models, _ := ioutil.ReadFile(filepath.Join(out, "models.go"))
+
+	return string(models)
+}
+
+func TestRun_RefArrayBody(t *testing.T) {
+	models := buildGenerated(t, `
+paths:
+  /offers/{id}/tags:
+    put:
+      operationId: setTags
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Tags'
+components:
+  schemas:
+    Tags:
+      type: array
+      items:
+        type: string
+`)
+
+	assert.Contains(t, models, "type Tags []string")
+	assert.NotContains(t, models, "UnmarshalSetTags")
+}
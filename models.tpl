@@ -0,0 +1,35 @@
+package {{ .Package }}
+
+import (
+{{- if .HasRequests }}
+	"context"
+	"net/http"
+{{- end }}
+{{- if .UsesTime }}
+	"time"
+{{- end }}
+{{- if .HasRequests }}
+
+	"github.com/beng90/spec2go/validate"
+	"github.com/go-playground/validator/v10"
+{{- end }}
+)
+{{ range .Components }}{{ template "model" . }}{{ end }}
+{{- range .Validators }}{{ if .Model }}{{ range .Models }}{{ template "model" . }}{{ end }}{{ end }}{{ if .HasUnmarshal }}
+// Unmarshal{{ .Operation }} validates request and decodes its body.
+func Unmarshal{{ .Operation }}(v *validator.Validate, req *http.Request, ctx context.Context) (*{{ .Model }}, error) {
+	model := &{{ .Model }}{}
+	if err := validate.Unmarshal(v, req, ctx, {{ .Name }}, model); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+{{ end }}{{ end }}
+{{- define "model" }}
+{{ if .Fields }}type {{ .Name }} struct {
+{{- range .Fields }}
+	{{ .Name }} {{ .Type }} `json:"{{ .JSONName }}{{ if .OmitEmpty }},omitempty{{ end }}"`
+{{- end }}
+}{{ else }}type {{ .Name }} {{ .Type }}{{ end }}
+{{ end }}
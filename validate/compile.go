@@ -0,0 +1,207 @@
+package validate
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Schema is a compiled request body schema. Rules are split, patterns
+// compiled and paths arranged in a tree once, so Schema is built at package
+// init and validates many requests. It is immutable and safe for concurrent
+// use.
+type Schema struct {
+	rules                RulesMap
+	additionalProperties RulesMap
+	compositions         []Composition
+	order                map[string]int
+	messages             map[string]map[string]string
+	tree                 *ruleTree
+}
+
+// NewSchema compiles rules, closed objects and compositions of request body.
+func NewSchema(rules, additionalProperties []FieldRule, compositions []Composition) *Schema {
+	builder := &SchemaValidator{}
+
+	for _, rule := range rules {
+		builder.AddRule(rule.Field, rule.Rule, rule.Pattern)
+		builder.AddMessages(rule.Field, rule.Messages)
+	}
+
+	for _, rule := range additionalProperties {
+		builder.AddAdditionalProperties(rule.Field, rule.Rule, rule.Pattern)
+	}
+
+	for _, composition := range compositions {
+		builder.AddComposition(composition)
+	}
+
+	return &Schema{
+		rules:                builder.rules,
+		additionalProperties: builder.additionalProperties,
+		compositions:         builder.compositions,
+		order:                builder.order,
+		messages:             builder.messages,
+		tree:                 builder.ruleTree(),
+	}
+}
+
+// Validate validates JSON body of request. Messages are translated when
+// Translations are used. Body is streamed by ValidateStream when limits are
+// set in context with WithStream.
+func (s *Schema) Validate(v *validator.Validate, req *http.Request, ctx context.Context) error {
+	if config, ok := streamConfig(ctx); ok {
+		return s.ValidateStream(v, req, ctx, config)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	requestBody, err := getRequestBody(req)
+	if err != nil {
+		return err
+	}
+
+	return s.validator(v, requestBody, ctx, req).Validate()
+}
+
+// validator returns validator of request body sharing compiled rules.
+func (s *Schema) validator(v *validator.Validate, requestBody MapField, ctx context.Context, req *http.Request) *SchemaValidator {
+	return &SchemaValidator{
+		validator:            v,
+		requestBody:          requestBody,
+		rules:                s.rules,
+		errors:               make(ValidationErrors),
+		context:              ctx,
+		additionalProperties: s.additionalProperties,
+		compositions:         s.compositions,
+		order:                s.order,
+		translator:           requestTranslator(req),
+		messages:             s.messages,
+		tree:                 s.tree,
+	}
+}
+
+// ruleTree arranges rule paths for a single walk over request body.
+type ruleTree struct {
+	root *ruleNode
+	// declared are rule paths and paths of their parents
+	declared map[string]bool
+}
+
+// ruleNode is a segment of rule paths. Rules sharing a prefix are walked
+// together, so request body is traversed once for all of them.
+type ruleNode struct {
+	// path is a rule ending at this node
+	path FieldPath
+	// segments are names of children in order of their rules
+	segments []string
+	children map[string]*ruleNode
+	// nested are rules ending below this node
+	nested []FieldPath
+	// shallow is set when rule ending at node checks only presence and size
+	// of value, so values of its children are not kept for it
+	shallow bool
+}
+
+// ruleTree arranges rule paths in a tree.
+func (s *SchemaValidator) ruleTree() *ruleTree {
+	root := &ruleNode{children: make(map[string]*ruleNode), shallow: true}
+	declared := make(map[string]bool)
+
+	for _, path := range s.orderedPaths() {
+		declared[path] = true
+		for i := range path {
+			if path[i] == '.' || strings.HasPrefix(path[i:], "[]") {
+				declared[path[:i]] = true
+			}
+		}
+
+		rulePath := s.rules[path].Path
+		node := root
+
+		for i, segment := range rulePath {
+			child, ok := node.children[segment]
+			if !ok {
+				child = &ruleNode{children: make(map[string]*ruleNode), shallow: true}
+				node.children[segment] = child
+				node.segments = append(node.segments, segment)
+			}
+
+			if i == len(rulePath)-1 {
+				child.path = rulePath
+				child.shallow = shallowRules(s.rules[path].Rules)
+			} else {
+				child.nested = append(child.nested, rulePath)
+			}
+
+			node = child
+		}
+	}
+
+	return &ruleTree{root, declared}
+}
+
+// collectValues walks request body along rule tree and passes values of all
+// rules below node to collect function. Path segment of node children is at index of rule
+// paths.
+func (s *SchemaValidator) collectValues(node *ruleNode, index int, fieldsTree FieldsArray, collect func(FieldSchema), path FieldPath) {
+	for _, segment := range node.segments {
+		s.collectSegment(node, segment, index, fieldsTree, collect, path)
+	}
+}
+
+// collectSegment passes values of rules of node child under segment to
+// collect function.
+func (s *SchemaValidator) collectSegment(node *ruleNode, segment string, index int, fieldsTree FieldsArray, collect func(FieldSchema), path FieldPath) {
+	child := node.children[segment]
+
+	if child.path != nil {
+		s.getValue(child.path, index, fieldsTree, collect, copyPath(path))
+	}
+
+	if len(child.nested) == 0 {
+		return
+	}
+
+	value := fieldsTree.last().Get(segment)
+
+	switch {
+	case value.Properties != nil:
+		s.collectValues(child, index+1, append(fieldsTree[:len(fieldsTree):len(fieldsTree)], value.Properties), collect, append(copyPath(path), segment))
+	case value.Items != nil:
+		name := strings.Trim(segment, "[]")
+
+		for i, item := range value.Items {
+			s.collectValues(child, index+1, append(fieldsTree[:len(fieldsTree):len(fieldsTree)], item), collect, append(copyPath(path), name+"["+strconv.Itoa(i)+"]"))
+		}
+	default:
+		// missing parent is reported by every nested rule
+		for _, nested := range child.nested {
+			s.getValue(nested, index, fieldsTree, collect, copyPath(path))
+		}
+	}
+}
+
+// shallowRules reports whether rules check only presence and size of value.
+func shallowRules(rules Rules) bool {
+	for _, rule := range rules {
+		name := strings.SplitN(rule, "=", 2)[0]
+
+		switch name {
+		case "required", "omitempty", RuleNullable, "object", "min", "max", "len":
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func copyPath(path FieldPath) FieldPath {
+	return append(make(FieldPath, 0, len(path)+1), path...)
+}
@@ -0,0 +1,187 @@
+package validate_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/validate"
+)
+
+var offerRules = []validate.FieldRule{
+	{Field: "productName", Rule: "required,string,min=1,max=255"},
+	{Field: "categoryId", Rule: "required,string", Pattern: validate.Pattern(`^\d+_\d+$`), Messages: map[string]string{"regexp": "Category id must look like 435_12"}},
+	{Field: "variants", Rule: "required"},
+	{Field: "variants[].price", Rule: "required,string"},
+	{Field: "variants[].inventory", Rule: "required"},
+	{Field: "variants[].inventory.size", Rule: "required,integer,min=1"},
+	{Field: "variants[].tags", Rule: "omitempty"},
+	{Field: "variants[].tags[]", Rule: "omitempty,string,max=8"},
+}
+
+var offerAdditionalProperties = []validate.FieldRule{
+	{Field: ""},
+	{Field: "variants[]"},
+}
+
+func getOfferBody(variants int) string {
+	items := make([]string, variants)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"price": "%d.99", "inventory": {"size": %d}, "tags": ["new", "sale"]}`, i, i+1)
+	}
+
+	return `{"productName": "Shirt", "categoryId": "435_12", "variants": [` + strings.Join(items, ",") + `]}`
+}
+
+func TestSchema_Validate(t *testing.T) {
+	schema := validate.NewSchema(offerRules, offerAdditionalProperties, nil)
+	v := NewValidator()
+
+	testData := []struct {
+		body string
+		want string
+	}{
+		{getOfferBody(3), ""},
+		{`{"categoryId": "a", "variants": [{"price": 1, "inventory": {}, "tags": ["too long tag"]}, {"price": "1", "color": "red"}], "extra": true}`,
+			"Field 'productName' failed in 'required' rule; " +
+				"Category id must look like 435_12; " +
+				"Field 'variants[1].color' failed in 'additionalProperties' rule; " +
+				"Field 'variants[0].price' failed in 'string' rule; " +
+				"Field 'variants[1].inventory' failed in 'required' rule; " +
+				"Field 'variants[0].inventory.size' failed in 'required' rule; " +
+				"Field 'variants[0].tags[0]' failed in 'max' rule, available values: 8; " +
+				"Field 'extra' failed in 'additionalProperties' rule"},
+	}
+
+	for _, tt := range testData {
+		req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+		err := schema.Validate(v, req, context.Background())
+
+		if tt.want == "" {
+			assert.Nil(t, err)
+
+			continue
+		}
+
+		if assert.Error(t, err) {
+			assert.Equal(t, tt.want, err.Error())
+		}
+	}
+}
+
+func TestSchema_Validate_Compositions(t *testing.T) {
+	compositions := []validate.Composition{variantComposition, priceComposition}
+	schema := validate.NewSchema([]validate.FieldRule{{Field: "price", Rule: "omitempty"}, {Field: "variants", Rule: "omitempty"}}, nil, compositions)
+	v := NewValidator()
+
+	// compiled branches are reused by every request
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"price": "1.0", "variants": [{"kind": "digital", "weight": 1}]}`))
+		err := schema.Validate(v, req, context.Background())
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "Field 'price' failed in 'oneOf' rule, available values: Price, Cents "+
+				"('Cents': Field 'price' failed in 'integer' rule, 'Price': Field 'price' failed in 'regexp' rule, available values: ^\\d+\\.\\d{2}$); "+
+				"Field 'variants[0].url' failed in 'required' rule", err.Error())
+		}
+	}
+
+	// compositions of caller are not changed
+	assert.Equal(t, []validate.Composition{variantComposition, priceComposition}, compositions)
+}
+
+func TestSchema_Validate_Concurrent(t *testing.T) {
+	schema := validate.NewSchema(offerRules, offerAdditionalProperties, nil)
+	v := NewValidator()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			body := getOfferBody(i)
+			if i%2 == 1 {
+				body = `{"productName": "Shirt", "categoryId": "1", "variants": []}`
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+			err := schema.Validate(v, req, context.Background())
+
+			if i%2 == 1 {
+				assert.EqualError(t, err, "Category id must look like 435_12")
+			} else {
+				assert.Nil(t, err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func BenchmarkSchemaValidator_Validate(b *testing.B) {
+	body := []byte(getOfferBody(500))
+	v := NewValidator()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+
+		schemaValidator, _ := validate.NewSchemaValidator(v, req, context.Background())
+		for _, rule := range offerRules {
+			schemaValidator.AddRule(rule.Field, rule.Rule, rule.Pattern)
+			schemaValidator.AddMessages(rule.Field, rule.Messages)
+		}
+		for _, rule := range offerAdditionalProperties {
+			schemaValidator.AddAdditionalProperties(rule.Field, rule.Rule, rule.Pattern)
+		}
+
+		if err := schemaValidator.Validate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkValidatePerRule is a baseline of validation before rules were
+// compiled into Schema.
+func BenchmarkValidatePerRule(b *testing.B) {
+	body := []byte(getOfferBody(500))
+	v := NewValidator()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+
+		if err := validate.ValidatePerRule(v, req, offerRules, offerAdditionalProperties); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSchema_Validate(b *testing.B) {
+	body := []byte(getOfferBody(500))
+	v := NewValidator()
+	schema := validate.NewSchema(offerRules, offerAdditionalProperties, nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+
+		if err := schema.Validate(v, req, context.Background()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
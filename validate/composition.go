@@ -0,0 +1,278 @@
+package validate
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	KeywordOneOf = "oneOf"
+	KeywordAnyOf = "anyOf"
+	KeywordNot   = "not"
+
+	// branchRoot is a name under which composed value is validated by branch
+	branchRoot = "$"
+)
+
+// FieldRule is a rule for field under path relative to the composed value.
+// Empty path is the value itself.
+type FieldRule struct {
+	Field   string
+	Rule    string
+	Pattern *string
+	// Messages are spec-authored messages keyed by rule name
+	Messages map[string]string
+}
+
+// Composition validates value under Field path against its branches.
+type Composition struct {
+	Field         string
+	Keyword       string
+	Discriminator string
+	// Mapping maps discriminator values to branch names
+	Mapping  map[string]string
+	Branches []Branch
+}
+
+type Branch struct {
+	Name                 string
+	Rules                []FieldRule
+	AdditionalProperties []FieldRule
+	Compositions         []Composition
+	// schema is compiled rules of branch, it is set by AddComposition
+	schema *Schema
+}
+
+// AddComposition adds composition with branches compiled once, so they are
+// not built again for every validated value.
+func (s *SchemaValidator) AddComposition(composition Composition) {
+	branches := make([]Branch, len(composition.Branches))
+	for i, branch := range composition.Branches {
+		branch.schema = newRootSchema(branch.Rules, branch.AdditionalProperties, branch.Compositions)
+		branches[i] = branch
+	}
+	composition.Branches = branches
+
+	s.compositions = append(s.compositions, composition)
+}
+
+func (s *SchemaValidator) validateCompositions() {
+	root := s.requestBody.Value()
+
+	for _, composition := range s.compositions {
+		for _, value := range collectValues(root, strings.Split(composition.Field, "."), "") {
+			if s.limitReached() {
+				return
+			}
+
+			s.validateComposition(composition, value.name, value.value)
+		}
+	}
+}
+
+func (s *SchemaValidator) validateComposition(composition Composition, fieldName string, value interface{}) {
+	// missing values are checked by required rule
+	if value == nil {
+		return
+	}
+
+	if composition.Discriminator != "" && len(composition.Mapping) > 0 {
+		s.validateDiscriminator(composition, fieldName, value)
+
+		return
+	}
+
+	var passed []string
+	failed := make(map[string]ValidationErrors)
+
+	for _, branch := range composition.Branches {
+		if errs := s.validateBranch(branch, fieldName, value); len(errs) > 0 {
+			failed[branch.Name] = errs
+		} else {
+			passed = append(passed, branch.Name)
+		}
+	}
+
+	fieldError := FieldError{
+		Field:    fieldName,
+		Rule:     composition.Keyword,
+		Value:    value,
+		Accepted: strings.Join(composition.branchNames(), ", "),
+	}
+
+	switch composition.Keyword {
+	case KeywordOneOf:
+		if len(passed) == 1 {
+			return
+		}
+		if len(passed) == 0 {
+			fieldError.Branches = failed
+		}
+	case KeywordAnyOf:
+		if len(passed) > 0 {
+			return
+		}
+		fieldError.Branches = failed
+	case KeywordNot:
+		if len(passed) == 0 {
+			return
+		}
+		fieldError.Accepted = ""
+	}
+
+	s.errors[fieldName] = append(s.errors[fieldName], fieldError)
+}
+
+// validateDiscriminator validates value only against branch pointed by
+// discriminator property.
+func (s *SchemaValidator) validateDiscriminator(composition Composition, fieldName string, value interface{}) {
+	object, _ := value.(map[string]interface{})
+	discriminator := object[composition.Discriminator]
+	discriminatorValue, _ := discriminator.(string)
+
+	if branchName, ok := composition.Mapping[discriminatorValue]; ok {
+		for _, branch := range composition.Branches {
+			if branch.Name != branchName {
+				continue
+			}
+
+			for name, errs := range s.validateBranch(branch, fieldName, value) {
+				s.errors[name] = append(s.errors[name], errs...)
+			}
+
+			return
+		}
+	}
+
+	values := make([]string, 0, len(composition.Mapping))
+	for mappingValue := range composition.Mapping {
+		values = append(values, mappingValue)
+	}
+	sort.Strings(values)
+
+	name := joinPath(fieldName, composition.Discriminator)
+	s.errors[name] = append(s.errors[name], FieldError{
+		Field:    name,
+		Rule:     "discriminator",
+		Value:    plainValue(discriminator),
+		Accepted: strings.Join(values, ", "),
+	})
+}
+
+// validateBranch validates value with compiled rules of branch and returns
+// errors named after fields of the request body.
+func (s *SchemaValidator) validateBranch(branch Branch, fieldName string, value interface{}) ValidationErrors {
+	schema := branch.schema
+	if schema == nil {
+		schema = newRootSchema(branch.Rules, branch.AdditionalProperties, branch.Compositions)
+	}
+
+	return schema.validateRoot(s.validator, s.context, value, fieldName)
+}
+
+// newRootSchema compiles rules relative to validated value.
+func newRootSchema(rules, additionalProperties []FieldRule, compositions []Composition) *Schema {
+	rootRules := make([]FieldRule, len(rules))
+	for i, rule := range rules {
+		rule.Field = branchPath(rule.Field)
+		rootRules[i] = rule
+	}
+
+	rootAdditionalProperties := make([]FieldRule, len(additionalProperties))
+	for i, rule := range additionalProperties {
+		rule.Field = branchPath(rule.Field)
+		rootAdditionalProperties[i] = rule
+	}
+
+	rootCompositions := make([]Composition, len(compositions))
+	for i, composition := range compositions {
+		composition.Field = branchPath(composition.Field)
+		rootCompositions[i] = composition
+	}
+
+	return NewSchema(rootRules, rootAdditionalProperties, rootCompositions)
+}
+
+// validateRoot validates decoded JSON value with schema of root rules and
+// returns errors with fieldName prefixed to their paths.
+func (s *Schema) validateRoot(v *validator.Validate, ctx context.Context, value interface{}, fieldName string) ValidationErrors {
+	rootValidator := s.validator(v, MapField{branchRoot: newFieldSchema(value)}, ctx, nil)
+
+	if rootValidator.Validate() == nil {
+		return nil
+	}
+
+	errs := make(ValidationErrors)
+	for name, fieldErrors := range rootValidator.errors {
+		name = fieldName + strings.TrimPrefix(name, branchRoot)
+		name = strings.TrimPrefix(name, ".")
+
+		for _, fieldError := range fieldErrors {
+			fieldError.Field = name
+			errs[name] = append(errs[name], fieldError)
+		}
+	}
+
+	return errs
+}
+
+func (c Composition) branchNames() []string {
+	names := make([]string, 0, len(c.Branches))
+	for _, branch := range c.Branches {
+		names = append(names, branch.Name)
+	}
+
+	return names
+}
+
+func branchPath(path string) string {
+	if path == "" || strings.HasPrefix(path, "[") {
+		return branchRoot + path
+	}
+
+	return branchRoot + "." + path
+}
+
+type collectedValue struct {
+	name  string
+	value interface{}
+}
+
+// collectValues returns values under rule path, with array indexes in names.
+func collectValues(value interface{}, path []string, name string) []collectedValue {
+	if len(path) == 0 || (len(path) == 1 && path[0] == "") {
+		return []collectedValue{{name, value}}
+	}
+
+	segment := path[0]
+	key := strings.TrimSuffix(segment, "[]")
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	child, ok := object[key]
+	if !ok {
+		return nil
+	}
+
+	name = joinPath(name, key)
+
+	if !strings.HasSuffix(segment, "[]") {
+		return collectValues(child, path[1:], name)
+	}
+
+	items, _ := child.([]interface{})
+
+	var values []collectedValue
+	for i, item := range items {
+		values = append(values, collectValues(item, path[1:], name+"["+strconv.Itoa(i)+"]")...)
+	}
+
+	return values
+}
@@ -0,0 +1,114 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/validate"
+)
+
+var variantComposition = validate.Composition{
+	Field:         "variants[]",
+	Keyword:       validate.KeywordOneOf,
+	Discriminator: "kind",
+	Mapping:       map[string]string{"physical": "Physical", "digital": "Digital"},
+	Branches: []validate.Branch{
+		{
+			Name: "Physical",
+			Rules: []validate.FieldRule{
+				{Field: "kind", Rule: "required,string", Pattern: nil},
+				{Field: "weight", Rule: "required,integer", Pattern: nil},
+			},
+			AdditionalProperties: []validate.FieldRule{{Field: "", Rule: "", Pattern: nil}},
+		},
+		{
+			Name: "Digital",
+			Rules: []validate.FieldRule{
+				{Field: "kind", Rule: "required,string", Pattern: nil},
+				{Field: "url", Rule: "required,string,url", Pattern: nil},
+			},
+		},
+	},
+}
+
+var priceComposition = validate.Composition{
+	Field:   "price",
+	Keyword: validate.KeywordOneOf,
+	Branches: []validate.Branch{
+		{Name: "Price", Rules: []validate.FieldRule{{Field: "", Rule: "omitempty,string", Pattern: validate.Pattern(`^\d+\.\d{2}$`)}}},
+		{Name: "Cents", Rules: []validate.FieldRule{{Field: "", Rule: "omitempty,integer", Pattern: nil}}},
+	},
+}
+
+func TestSchemaValidator_Validate_Composition(t *testing.T) {
+	testData := []struct {
+		input  string
+		fields []string
+		rules  []string
+	}{
+		{`{"price": "1.00", "variants": [{"kind": "physical", "weight": 1}]}`, nil, nil},
+		{`{"price": 100, "variants": [{"kind": "digital", "url": "https://a.b"}]}`, nil, nil},
+		{`{"variants": [{"kind": "physical", "weight": 1, "url": "https://a.b"}]}`, []string{"variants[0].url"}, []string{"additionalProperties"}},
+		{`{"variants": [{"kind": "digital", "weight": 1}]}`, []string{"variants[0].url"}, []string{"required"}},
+		{`{"variants": [{"kind": "other"}]}`, []string{"variants[0].kind"}, []string{"discriminator"}},
+		{`{"price": "1.0"}`, []string{"price"}, []string{"oneOf"}},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.input, func(t *testing.T) {
+			schemaValidator := getSchemaValidator(tt.input)
+			schemaValidator.AddRule("price", "omitempty", nil)
+			schemaValidator.AddRule("variants", "omitempty", nil)
+			schemaValidator.AddComposition(variantComposition)
+			schemaValidator.AddComposition(priceComposition)
+			err := schemaValidator.Validate()
+
+			if tt.fields == nil {
+				assert.Nil(t, err)
+
+				return
+			}
+
+			errs := err.(validate.ValidationErrors)
+			assert.Len(t, errs, len(tt.fields))
+			for i, field := range tt.fields {
+				if assert.Len(t, errs[field], 1) {
+					assert.Equal(t, tt.rules[i], errs[field][0].Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaValidator_Validate_CompositionBranches(t *testing.T) {
+	schemaValidator := getSchemaValidator(`{"price": true}`)
+	schemaValidator.AddRule("price", "omitempty", nil)
+	schemaValidator.AddComposition(priceComposition)
+	err := schemaValidator.Validate().(validate.ValidationErrors)
+
+	fieldError := err["price"][0]
+	assert.Equal(t, "oneOf", fieldError.Rule)
+	assert.Equal(t, "Price, Cents", fieldError.Accepted)
+	assert.Equal(t, "string", fieldError.Branches["Price"]["price"][0].Rule)
+	assert.Equal(t, "integer", fieldError.Branches["Cents"]["price"][0].Rule)
+	assert.Equal(t, "Field 'price' failed in 'oneOf' rule, available values: Price, Cents "+
+		"('Cents': Field 'price' failed in 'integer' rule, 'Price': Field 'price' failed in 'string' rule)", fieldError.Error())
+}
+
+func TestSchemaValidator_Validate_Not(t *testing.T) {
+	not := validate.Composition{
+		Field:    "code",
+		Keyword:  validate.KeywordNot,
+		Branches: []validate.Branch{{Name: "not", Rules: []validate.FieldRule{{Field: "", Rule: "omitempty,oneof=admin", Pattern: nil}}}},
+	}
+
+	schemaValidator := getSchemaValidator(`{"code": "admin"}`)
+	schemaValidator.AddComposition(not)
+	err := schemaValidator.Validate().(validate.ValidationErrors)
+	assert.Equal(t, "not", err["code"][0].Rule)
+
+	schemaValidator = getSchemaValidator(`{"code": "user"}`)
+	schemaValidator.AddComposition(not)
+	assert.Nil(t, schemaValidator.Validate())
+}
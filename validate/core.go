@@ -4,22 +4,33 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
-	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 )
 
 type SchemaValidator struct {
-	validator   *validator.Validate
-	requestBody MapField
-	rules       RulesMap
-	errors      ValidationErrors
-	context     context.Context
+	validator            *validator.Validate
+	requestBody          MapField
+	rules                RulesMap
+	errors               ValidationErrors
+	context              context.Context
+	additionalProperties RulesMap
+	compositions         []Composition
+	// order is a position of rule path in order rules were added
+	order      map[string]int
+	translator ut.Translator
+	// messages are spec-authored messages of rules keyed by rule path
+	messages map[string]map[string]string
+	// tree arranges rule paths, it is built on validation after rules change
+	tree *ruleTree
+	// maxErrors stops validation after given number of errors
+	maxErrors int
 }
 
 type RulesMap map[string]Rule
@@ -27,6 +38,8 @@ type Rule struct {
 	Path    FieldPath
 	Rules   Rules
 	Pattern *string
+	// matcher is compiled Pattern, it is shared by copies of rule
+	matcher *patternMatcher
 }
 
 func (r *Rule) Has(name string) bool {
@@ -81,11 +94,20 @@ func getRequestBody(req *http.Request) (requestBody MapField, err error) {
 		return nil, ErrInvalidJSON
 	}
 
-	if err := json.Unmarshal(buffer, &requestBody); err != nil {
+	// numbers keep their text, so integers do not lose precision
+	decoder := json.NewDecoder(bytes.NewReader(buffer))
+	decoder.UseNumber()
+
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
 		return nil, err
 	}
 
-	return requestBody, nil
+	if object == nil {
+		return nil, nil
+	}
+
+	return newMapField(object), nil
 }
 
 func NewSchemaValidator(v *validator.Validate, req *http.Request, ctx context.Context) (schemaValidator *SchemaValidator, err error) {
@@ -104,6 +126,13 @@ func NewSchemaValidator(v *validator.Validate, req *http.Request, ctx context.Co
 		make(RulesMap),
 		make(ValidationErrors),
 		ctx,
+		make(RulesMap),
+		nil,
+		make(map[string]int),
+		requestTranslator(req),
+		make(map[string]map[string]string),
+		nil,
+		0,
 	}
 
 	return
@@ -114,9 +143,50 @@ func (s *SchemaValidator) AddRule(path string, rule string, pattern *string) {
 		s.rules = make(RulesMap)
 	}
 
+	if s.order == nil {
+		s.order = make(map[string]int)
+	}
+
+	if _, ok := s.order[path]; !ok {
+		s.order[path] = len(s.order)
+	}
+
+	s.tree = nil
+
 	rulesSlice := strings.Split(rule, ",")
 	pathSlice := strings.Split(path, ".")
-	s.rules[path] = Rule{pathSlice, rulesSlice, pattern}
+	s.rules[path] = Rule{pathSlice, rulesSlice, pattern, newPatternMatcher(pattern)}
+}
+
+// AddMessages sets messages of failed rules of field under given path. They
+// are keyed by rule name, message keyed by empty string is used for any rule.
+func (s *SchemaValidator) AddMessages(path string, messages map[string]string) {
+	if len(messages) == 0 {
+		return
+	}
+
+	if s.messages == nil {
+		s.messages = make(map[string]map[string]string)
+	}
+
+	s.messages[path] = messages
+}
+
+// AddAdditionalProperties closes object under given path, so only properties
+// having a rule are accepted. When rule is not empty, additional properties
+// are accepted if they pass it.
+func (s *SchemaValidator) AddAdditionalProperties(path string, rule string, pattern *string) {
+	if s.additionalProperties == nil {
+		s.additionalProperties = make(RulesMap)
+	}
+
+	var rulesSlice []string
+	if rule != "" {
+		rulesSlice = strings.Split(rule, ",")
+	}
+
+	pathSlice := strings.Split(path, ".")
+	s.additionalProperties[path] = Rule{pathSlice, rulesSlice, pattern, newPatternMatcher(pattern)}
 }
 
 func (s *SchemaValidator) HasRule(path []string) bool {
@@ -140,13 +210,12 @@ func (s *SchemaValidator) fieldPath(path []string) string {
 }
 
 func (s *SchemaValidator) ruleName(path []string) string {
-	var re = regexp.MustCompile(`(?m)\[(\d)\]`)
-	ruleName := re.ReplaceAllString(strings.Join(path, "."), `[]`)
+	ruleName := arrayIndexRegexp.ReplaceAllString(strings.Join(path, "."), `[]`)
 
 	return ruleName
 }
 
-func (s *SchemaValidator) getValue(exploded FieldPath, index int, fieldsTree FieldsArray, values *[]FieldSchema, path FieldPath) {
+func (s *SchemaValidator) getValue(exploded FieldPath, index int, fieldsTree FieldsArray, collect func(FieldSchema), path FieldPath) {
 	fieldName := exploded[index]
 	lastValue := fieldsTree.last()
 	rule := s.rules[exploded.String()]
@@ -172,7 +241,7 @@ func (s *SchemaValidator) getValue(exploded FieldPath, index int, fieldsTree Fie
 					singleItem.Name = path.String() + "[" + strconv.Itoa(i) + "]"
 					singleItem.Rules = rules
 					singleItem.Rule = rule
-					*values = append(*values, singleItem)
+					collect(singleItem)
 				}
 
 				return
@@ -180,9 +249,10 @@ func (s *SchemaValidator) getValue(exploded FieldPath, index int, fieldsTree Fie
 
 			// there is no items in array
 			parent.Value = nil
+			parent.Present = false
 			parent.Name = path.String()
 			parent.Rule = rule
-			*values = append(*values, parent)
+			collect(parent)
 
 			return
 		}
@@ -191,7 +261,7 @@ func (s *SchemaValidator) getValue(exploded FieldPath, index int, fieldsTree Fie
 		current.Name = path.String()
 		current.Rules = rules
 		current.Rule = rule
-		*values = append(*values, current)
+		collect(current)
 
 		return
 	}
@@ -202,7 +272,7 @@ func (s *SchemaValidator) getValue(exploded FieldPath, index int, fieldsTree Fie
 		fieldsMap := fieldsTree.last().Get(fieldName).Properties
 		fieldsTree = append(fieldsTree, fieldsMap)
 
-		s.getValue(exploded, index+1, fieldsTree, values, path)
+		s.getValue(exploded, index+1, fieldsTree, collect, path)
 
 		path = path[:len(path)-1]
 
@@ -212,12 +282,18 @@ func (s *SchemaValidator) getValue(exploded FieldPath, index int, fieldsTree Fie
 			path.add(strings.Trim(fieldName, "[]") + "[" + strconv.Itoa(i) + "]")
 			fieldsTree = append(fieldsTree, item)
 
-			s.getValue(exploded, index+1, fieldsTree, values, path)
+			s.getValue(exploded, index+1, fieldsTree, collect, path)
 
 			fieldsTree = fieldsTree[:len(fieldsTree)-1]
 			path = path[:len(path)-1]
 		}
 	} else {
+		// nested fields of null allowed by nullable rule are not validated
+		parentRule := s.rules[strings.Join(exploded[:index+1], ".")]
+		if parent.Present && parent.Value == nil && parentRule.Has(RuleNullable) {
+			return
+		}
+
 		// not last element - without nodes
 		if len(path) > 0 {
 			if len(path) < len(exploded) {
@@ -238,7 +314,7 @@ func (s *SchemaValidator) getValue(exploded FieldPath, index int, fieldsTree Fie
 
 		parentRules := s.GetRule(path)
 		if parentRules.Has("required") {
-			*values = append(*values, parent)
+			collect(parent)
 		}
 	}
 
@@ -246,61 +322,258 @@ func (s *SchemaValidator) getValue(exploded FieldPath, index int, fieldsTree Fie
 
 func (s *SchemaValidator) Validate() error {
 	data := FieldsArray{s.requestBody}
-	values := &[]FieldSchema{}
 
-	for _, rule := range s.rules {
-		s.getValue(rule.Path, 0, data, values, []string{})
+	if s.tree == nil {
+		s.tree = s.ruleTree()
+	}
+
+	s.collectValues(s.tree.root, 0, data, s.validateField, FieldPath{})
+
+	s.validateAdditionalProperties(s.requestBody, "", "")
+	s.validateCompositions()
+
+	return s.result()
+}
+
+// result returns errors ordered by their rules, with messages declared in
+// spec or translated.
+func (s *SchemaValidator) result() error {
+	if len(s.errors) > 0 {
+		s.errors.setOrder(s.fieldOrder)
+		s.errors.setMessages(s.fieldMessage)
+
+		if s.translator != nil {
+			s.errors.translate(s.translator)
+		}
+
+		return s.errors
+	}
+
+	return nil
+}
+
+// fieldMessage returns message of failed rule declared for field. Message of
+// not allowed additional property is declared by its object.
+func (s *SchemaValidator) fieldMessage(fieldName, rule string) (string, bool) {
+	path := s.ruleName([]string{fieldName})
+
+	if rule == "additionalProperties" {
+		path = ""
+		if position := strings.LastIndex(fieldName, "."); position >= 0 {
+			path = s.ruleName([]string{fieldName[:position]})
+		}
 	}
 
-	for _, field := range *values {
-		switch field.Value.(type) {
-		case bool:
-			err := s.validator.VarCtx(s.context, field.Value, field.Rules.ForBool().String())
-			s.errors.try(field.Name, err)
+	return ruleMessage(s.messages[path], rule)
+}
+
+// fieldOrder returns position of the closest rule of field, so errors of
+// nested values without own rule are placed next to their parent.
+func (s *SchemaValidator) fieldOrder(fieldName string) (int, bool) {
+	path := s.ruleName([]string{fieldName})
+
+	for path != "" {
+		if order, ok := s.order[path]; ok {
+			return order, true
+		}
+
+		switch {
+		case strings.HasSuffix(path, "[]"):
+			path = strings.TrimSuffix(path, "[]")
+		case strings.Contains(path, "."):
+			path = path[:strings.LastIndex(path, ".")]
 		default:
-			err := s.validator.VarCtx(s.context, field.Value, field.Rules.String())
-			s.errors.try(field.Name, err)
-
-			if field.Rule.Pattern != nil && field.Value != nil {
-				var fVal string
-				switch v := field.Value.(type) {
-				case float64:
-					fVal = fmt.Sprintf("%.4f", v)
-				default:
-					fVal = field.Value.(string)
-				}
+			path = ""
+		}
+	}
 
-				if fVal == "" {
-					break
-				}
+	return 0, false
+}
 
-				err := s.validatePattern(field.Name, *field.Rule.Pattern, fVal)
-				if err != nil {
-					s.errors[field.Name] = append(s.errors[field.Name], *err)
-				}
+// orderedPaths returns rule paths in order they were added.
+func (s *SchemaValidator) orderedPaths() []string {
+	paths := make([]string, 0, len(s.rules))
+	for path := range s.rules {
+		paths = append(paths, path)
+	}
+
+	sort.Slice(paths, func(i, j int) bool {
+		return s.order[paths[i]] < s.order[paths[j]]
+	})
+
+	return paths
+}
+
+func (s *SchemaValidator) validateField(field FieldSchema) {
+	if s.limitReached() {
+		return
+	}
+
+	// null differs from missing value, it is accepted by nullable rule only
+	// and fails required rule otherwise
+	if field.Present && field.Value == nil {
+		if field.Rules.Has(RuleNullable) {
+			return
+		}
+
+		if !field.Rules.Required() {
+			s.errors[field.Name] = append(s.errors[field.Name], FieldError{
+				Field: field.Name,
+				Rule:  RuleNullable,
+			})
+
+			return
+		}
+	}
+
+	switch field.Value.(type) {
+	case bool:
+		err := s.validator.VarCtx(s.context, field.Value, field.Rules.ForBool().String())
+		s.errors.try(field.Name, err)
+	default:
+		value := field.Value
+		if number, ok := value.(json.Number); ok {
+			value = numberValue(number, field.Rules)
+		}
+
+		err := s.validator.VarCtx(s.context, value, field.Rules.String())
+		s.errors.try(field.Name, err)
+
+		if items, ok := field.Value.([]interface{}); ok && field.Rules.Has(RuleUniqueItems) {
+			s.errors.unique(field.Name, items)
+		}
+
+		if field.Rule.matcher != nil && field.Value != nil {
+			var fVal string
+			switch v := field.Value.(type) {
+			case json.Number:
+				// pattern matches number as it is written in request
+				fVal = v.String()
+			default:
+				fVal, _ = field.Value.(string)
+			}
+
+			if fVal == "" {
+				break
+			}
+
+			err := validatePattern(field.Name, field.Rule.matcher, fVal)
+			if err != nil {
+				s.errors[field.Name] = append(s.errors[field.Name], *err)
 			}
 		}
 	}
+}
 
-	// TODO: sort errors by fieldname
-	if len(s.errors) > 0 {
-		return s.errors
+// validateAdditionalProperties walks request body and reports properties
+// which are not declared in closed objects.
+func (s *SchemaValidator) validateAdditionalProperties(fields MapField, rulePath, fieldPath string) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	return nil
+	for _, key := range keys {
+		if s.limitReached() {
+			return
+		}
+
+		s.validateAdditionalProperty(fields, key, rulePath, fieldPath)
+	}
 }
 
-func (s *SchemaValidator) validatePattern(fieldName, pattern, value string) *FieldError {
-	re := regexp.MustCompile(pattern)
-	isValid := re.MatchString(value)
+// validateAdditionalProperty reports property under key of object which is
+// not declared in closed object, or its nested properties otherwise.
+func (s *SchemaValidator) validateAdditionalProperty(fields MapField, key, rulePath, fieldPath string) {
+	additional, closed := s.additionalProperties[rulePath]
+
+	field := fields[key]
+	childRulePath := joinPath(rulePath, key)
+	childFieldPath := joinPath(fieldPath, key)
+
+	if !s.isDeclared(childRulePath) {
+		if !closed {
+			return
+		}
+
+		field.Name = childFieldPath
+		if additional.Rules == nil {
+			s.errors[field.Name] = append(s.errors[field.Name], FieldError{
+				Field: field.Name,
+				Rule:  "additionalProperties",
+				Value: plainValue(field.Value),
+			})
+
+			return
+		}
+
+		field.Rules = additional.Rules
+		field.Rule = additional
+		s.validateField(field)
+
+		return
+	}
+
+	if field.Properties != nil {
+		s.validateAdditionalProperties(field.Properties, childRulePath, childFieldPath)
+	}
+
+	for i, item := range field.Items {
+		// array of primitives
+		if item.Get("arrayItem").Type == "item" {
+			continue
+		}
+
+		s.validateAdditionalProperties(item, childRulePath+"[]", childFieldPath+"["+strconv.Itoa(i)+"]")
+	}
+}
+
+// limitReached reports whether validation is stopped by number of errors.
+func (s *SchemaValidator) limitReached() bool {
+	if s.maxErrors <= 0 {
+		return false
+	}
+
+	count := 0
+	for _, fieldErrors := range s.errors {
+		count += len(fieldErrors)
+	}
+
+	return count >= s.maxErrors
+}
+
+func (s *SchemaValidator) isDeclared(path string) bool {
+	if s.tree != nil {
+		return s.tree.declared[path]
+	}
+
+	for rulePath := range s.rules {
+		if rulePath == path || strings.HasPrefix(rulePath, path+".") || strings.HasPrefix(rulePath, path+"[]") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
 
-	if !isValid {
+// validatePattern matches value with compiled pattern of rule.
+func validatePattern(fieldName string, pattern *patternMatcher, value string) *FieldError {
+	if !pattern.MatchString(value) {
 		// return error
 		return &FieldError{
 			Field:            fieldName,
 			Rule:             "regexp",
 			Value:            value,
-			Accepted:         pattern,
+			Accepted:         pattern.pattern,
 			ValidationErrors: nil,
 		}
 	}
@@ -3,8 +3,10 @@ package validate_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"reflect"
 	"strings"
@@ -230,6 +232,54 @@ func TestSchemaValidator_Validate_Integer(t *testing.T) {
 			errorField: fieldName,
 			want:       getExpectedError(fieldName, "max", uint64(9223372036854775808), "9223372036854775807"),
 		},
+		{
+			rules:      "required,integer",
+			input:      fmt.Sprintf(`{"%s": 1.5}`, fieldName),
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "integer", 1.5, ""),
+		},
+		{
+			rules:      "required,integer",
+			input:      fmt.Sprintf(`{"%s": 2.0}`, fieldName),
+			errorField: fieldName,
+			want:       nil,
+		},
+		{
+			rules:      "required,integer,max=9007199254740993",
+			input:      fmt.Sprintf(`{"%s": 9007199254740994}`, fieldName),
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "max", int64(9007199254740994), "9007199254740993"),
+		},
+		{
+			rules:      "required,integer,int32",
+			input:      fmt.Sprintf(`{"%s": 2147483647}`, fieldName),
+			errorField: fieldName,
+			want:       nil,
+		},
+		{
+			rules:      "required,integer,int32",
+			input:      fmt.Sprintf(`{"%s": 2147483648}`, fieldName),
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "int32", float64(2147483648), ""),
+		},
+		{
+			rules:      "required,integer,int32",
+			input:      fmt.Sprintf(`{"%s": -2147483649}`, fieldName),
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "int32", float64(-2147483649), ""),
+		},
+		{
+			rules:      "required,integer,int64,min=-5",
+			input:      fmt.Sprintf(`{"%s": 9223372036854775807}`, fieldName),
+			errorField: fieldName,
+			want:       nil,
+		},
+		{
+			rules:      "required,integer,int64,min=-5",
+			input:      fmt.Sprintf(`{"%s": 9223372036854775808}`, fieldName),
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "int64", float64(9223372036854775808), ""),
+		},
 	}
 
 	for _, tt := range testData {
@@ -381,6 +431,187 @@ func TestSchemaValidator_Validate_Pattern(t *testing.T) {
 	}
 }
 
+func TestSchemaValidator_Validate_Number(t *testing.T) {
+	fieldName := "price"
+
+	testData := []Input{
+		{
+			rules:      "required,numeric,min=0.01,multipleOf=0.01",
+			input:      `{"price": 0.07}`,
+			errorField: fieldName,
+			want:       nil,
+		},
+		{
+			rules:      "required,numeric,min=0.01,multipleOf=0.01",
+			input:      `{"price": 1234567.89}`,
+			errorField: fieldName,
+			want:       nil,
+		},
+		{
+			rules:      "required,numeric,min=0.01,multipleOf=0.01",
+			input:      `{"price": 0.005}`,
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "min", 0.005, "0.01"),
+		},
+		{
+			rules:      "required,numeric,multipleOf=0.01",
+			input:      `{"price": 19.999}`,
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "multipleOf", 19.999, "0.01"),
+		},
+		{
+			rules:      "required,integer,multipleOf=5",
+			input:      `{"price": 9223372036854775805}`,
+			errorField: fieldName,
+			want:       nil,
+		},
+		{
+			rules:      "required,numeric,gt=0,lt=0.5",
+			input:      `{"price": 0.5}`,
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "lt", 0.5, "0.5"),
+		},
+		{
+			rules:      "required,numeric,float",
+			input:      `{"price": 3.4e38}`,
+			errorField: fieldName,
+			want:       nil,
+		},
+		{
+			rules:      "required,numeric,float",
+			input:      `{"price": 3.5e38}`,
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "float", 3.5e38, ""),
+		},
+		{
+			rules:      "required,numeric,double",
+			input:      `{"price": 1e309}`,
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "double", math.Inf(1), ""),
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.input, func(t *testing.T) {
+			schemaValidator := getSchemaValidator(tt.input)
+			schemaValidator.AddRule(fieldName, tt.rules, nil)
+			err := schemaValidator.Validate()
+
+			if err := tt.Test(t, err); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSchemaValidator_Validate_NumberPattern(t *testing.T) {
+	fieldName := "price"
+	pattern := `^\d+\.\d{2}$`
+
+	testData := []Input{
+		{
+			rules:      "required,numeric",
+			pattern:    pattern,
+			input:      `{"price": 10.50}`,
+			errorField: fieldName,
+			want:       nil,
+		},
+		{
+			rules:      "required,numeric",
+			pattern:    pattern,
+			input:      `{"price": 10.5}`,
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "regexp", "10.5", pattern),
+		},
+		{
+			rules:      "required,numeric",
+			pattern:    pattern,
+			input:      `{"price": 10}`,
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "regexp", "10", pattern),
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.input, func(t *testing.T) {
+			schemaValidator := getSchemaValidator(tt.input)
+			schemaValidator.AddRule(fieldName, tt.rules, &tt.pattern)
+			err := schemaValidator.Validate()
+
+			if err := tt.Test(t, err); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSchemaValidator_Validate_ArrayItems(t *testing.T) {
+	fieldName := "tags"
+
+	testData := []Input{
+		{
+			rules:      "required,min=1,max=3,uniqueItems",
+			input:      `{"tags": ["a", "b"]}`,
+			errorField: fieldName,
+			want:       nil,
+		},
+		{
+			rules:      "required,min=1,max=3,uniqueItems",
+			input:      `{"tags": []}`,
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "min", []interface{}{}, "1"),
+		},
+		{
+			rules:      "required,min=1,max=3,uniqueItems",
+			input:      `{"tags": ["a", "b", "c", "d"]}`,
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "max", []interface{}{"a", "b", "c", "d"}, "3"),
+		},
+		{
+			rules:      "required,uniqueItems",
+			input:      `{"tags": ["a", "b", "a"]}`,
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "uniqueItems", "a", "0, 2"),
+		},
+		{
+			rules:      "required,uniqueItems",
+			input:      `{"tags": [1, "1", 1.0]}`,
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "uniqueItems", float64(1), "0, 2"),
+		},
+		{
+			rules:      "required,uniqueItems",
+			input:      `{"tags": [0.5, 5E-1, 0, -0.0, 1e999999, 10e999998, 1e999998]}`,
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "uniqueItems", 0.5, "0, 1, 2, 3, 4, 5"),
+		},
+		{
+			rules:      "required,uniqueItems",
+			input:      `{"tags": [{"id": 1, "values": [1, 2]}, {"id": 2}, {"values": [1, 2], "id": 1}]}`,
+			errorField: fieldName,
+			want:       getExpectedError(fieldName, "uniqueItems", map[string]interface{}{"id": json.Number("1"), "values": []interface{}{json.Number("1"), json.Number("2")}}, "0, 2"),
+		},
+		{
+			rules:      "required,uniqueItems",
+			input:      `{"tags": [{"id": 1, "values": [1, 2]}, {"id": 1, "values": [2, 1]}]}`,
+			errorField: fieldName,
+			want:       nil,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.input, func(t *testing.T) {
+			schemaValidator := getSchemaValidator(tt.input)
+			schemaValidator.AddRule(fieldName, tt.rules, nil)
+			err := schemaValidator.Validate()
+
+			if err := tt.Test(t, err); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
 func TestSchemaValidator_Validate_ObjectItem(t *testing.T) {
 	fieldName := "category.id"
 
@@ -822,3 +1053,240 @@ func TestSchemaValidator_Validate_NestedArrayField_ParentRequired(t *testing.T)
 		})
 	}
 }
+
+func TestSchemaValidator_Validate_Enum(t *testing.T) {
+	testData := []Input{
+		{
+			rules:      "required,string,oneof=active 'on hold' a0x2Cb",
+			input:      `{"status": "on hold"}`,
+			errorField: "status",
+			want:       nil,
+		},
+		{
+			rules:      "required,string,oneof=active 'on hold' a0x2Cb",
+			input:      `{"status": "a,b"}`,
+			errorField: "status",
+			want:       nil,
+		},
+		{
+			rules:      "required,string,oneof=active 'on hold' a0x2Cb",
+			input:      `{"status": "hold"}`,
+			errorField: "status",
+			want:       getExpectedError("status", "oneof", "hold", "active, on hold, a,b"),
+		},
+		{
+			rules:      "required,integer,oneof=10 20",
+			input:      `{"status": 20}`,
+			errorField: "status",
+			want:       nil,
+		},
+		{
+			rules:      "required,integer,oneof=10 20",
+			input:      `{"status": 30}`,
+			errorField: "status",
+			want:       getExpectedError("status", "oneof", float64(30), "10, 20"),
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.input, func(t *testing.T) {
+			schemaValidator := getSchemaValidator(tt.input)
+			schemaValidator.AddRule(tt.errorField, tt.rules, nil)
+			err := schemaValidator.Validate()
+
+			if err := tt.Test(t, err); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSchemaValidator_Validate_AdditionalProperties(t *testing.T) {
+	testData := []Input{
+		{
+			input:      `{"name": "abc", "variants": [{"id": "1"}]}`,
+			errorField: "name",
+			want:       nil,
+		},
+		{
+			input:      `{"name": "abc", "foo": 1}`,
+			errorField: "foo",
+			want:       getExpectedError("foo", "additionalProperties", float64(1), ""),
+		},
+		{
+			input:      `{"variants": [{"id": "1"}, {"id": "2", "foo": true}]}`,
+			errorField: "variants[1].foo",
+			want:       getExpectedError("variants[1].foo", "additionalProperties", true, ""),
+		},
+		{
+			input:      `{"variants": [{"id": "1", "meta": {"color": "red"}}]}`,
+			errorField: "variants[0].meta.color",
+			want:       nil,
+		},
+		{
+			input:      `{"variants": [{"id": "1", "meta": {"color": 1}}]}`,
+			errorField: "variants[0].meta.color",
+			want:       getExpectedError("variants[0].meta.color", "string", float64(1), ""),
+		},
+		{
+			input:      `{"variants": [{"id": "1", "meta": {"color": "blue"}}]}`,
+			errorField: "variants[0].meta.color",
+			want:       getExpectedError("variants[0].meta.color", "regexp", "blue", `^r`),
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.input, func(t *testing.T) {
+			schemaValidator := getSchemaValidator(tt.input)
+			schemaValidator.AddRule("name", "omitempty,string", nil)
+			schemaValidator.AddRule("variants", "omitempty", nil)
+			schemaValidator.AddRule("variants[].id", "required,string", nil)
+			schemaValidator.AddRule("variants[].meta", "omitempty,object", nil)
+			schemaValidator.AddAdditionalProperties("", "", nil)
+			schemaValidator.AddAdditionalProperties("variants[]", "", nil)
+			schemaValidator.AddAdditionalProperties("variants[].meta", "omitempty,string", validate.Pattern(`^r`))
+			err := schemaValidator.Validate()
+
+			if err := tt.Test(t, err); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSchemaValidator_Validate_Nullable(t *testing.T) {
+	testData := []Input{
+		{
+			rules:      "required,nullable,string",
+			input:      `{"brand": null}`,
+			errorField: "brand",
+			want:       nil,
+		},
+		{
+			rules:      "required,nullable,string",
+			input:      `{}`,
+			errorField: "brand",
+			want:       getExpectedError("brand", "required", nil, ""),
+		},
+		{
+			rules:      "required,nullable,string",
+			input:      `{"brand": 1}`,
+			errorField: "brand",
+			want:       getExpectedError("brand", "string", float64(1), ""),
+		},
+		{
+			rules:      "omitempty,string",
+			input:      `{}`,
+			errorField: "brand",
+			want:       nil,
+		},
+		{
+			rules:      "omitempty,string",
+			input:      `{"brand": null}`,
+			errorField: "brand",
+			want:       getExpectedError("brand", "nullable", nil, ""),
+		},
+		{
+			rules:      "omitempty,nullable,string",
+			input:      `{"brand": null}`,
+			errorField: "brand",
+			want:       nil,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.input, func(t *testing.T) {
+			schemaValidator := getSchemaValidator(tt.input)
+			schemaValidator.AddRule(tt.errorField, tt.rules, nil)
+			err := schemaValidator.Validate()
+
+			if err := tt.Test(t, err); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSchemaValidator_Validate_NullableParent(t *testing.T) {
+	schemaValidator := getSchemaValidator(`{"product": null}`)
+	schemaValidator.AddRule("product", "required,nullable", nil)
+	schemaValidator.AddRule("product.name", "required,string", nil)
+
+	assert.Nil(t, schemaValidator.Validate())
+}
+
+func TestSchemaValidator_Validate_Sorted(t *testing.T) {
+	schemaValidator := getSchemaValidator(`{
+		"variants": [{"price": 1}, {}, {}, {}, {}, {}, {}, {}, {}, {}, {"price": "1", "size": "x"}],
+		"extra": 1
+	}`)
+	schemaValidator.AddRule("productName", "required,string", nil)
+	schemaValidator.AddRule("variants", "required", nil)
+	schemaValidator.AddRule("variants[].price", "required,string", nil)
+	schemaValidator.AddRule("variants[].size", "omitempty,integer", nil)
+	schemaValidator.AddRule("categoryId", "required,string", nil)
+	schemaValidator.AddAdditionalProperties("", "", nil)
+
+	err := schemaValidator.Validate()
+
+	var fields []string
+	for _, fieldError := range err.(validate.ValidationErrors).Sorted() {
+		fields = append(fields, fieldError.Field+":"+fieldError.Rule)
+	}
+
+	assert.Equal(t, []string{
+		"productName:required",
+		"variants[0].price:string",
+		"variants[1].price:required",
+		"variants[2].price:required",
+		"variants[3].price:required",
+		"variants[4].price:required",
+		"variants[5].price:required",
+		"variants[6].price:required",
+		"variants[7].price:required",
+		"variants[8].price:required",
+		"variants[9].price:required",
+		"variants[10].size:integer",
+		"categoryId:required",
+		"extra:additionalProperties",
+	}, fields)
+
+	assert.True(t, strings.HasPrefix(err.Error(), "Field 'productName' failed in 'required' rule; Field 'variants[0].price' failed in 'string' rule; "))
+	assert.True(t, strings.HasSuffix(err.Error(), "; Field 'extra' failed in 'additionalProperties' rule"))
+}
+
+func TestValidationErrors_Error(t *testing.T) {
+	assert.Equal(t, "", validate.ValidationErrors{}.Error())
+	assert.Equal(t, "Field 'a' failed in 'required' rule; Field 'b' failed in 'max' rule, available values: 5", validate.ValidationErrors{
+		"b": {{Field: "b", Rule: "max", Accepted: "5"}},
+		"a": {{Field: "a", Rule: "required"}},
+	}.Error())
+	assert.Equal(t, "Field 'tags' failed in 'uniqueItems' rule, duplicate items: 0, 2", validate.FieldError{Field: "tags", Rule: "uniqueItems", Accepted: "0, 2"}.Error())
+}
+
+func TestSchemaValidator_Validate_Messages(t *testing.T) {
+	schemaValidator := getSchemaValidator(`{"categoryId": "abc", "name": 1, "variant": {"extra": 1}}`)
+	schemaValidator.AddRule("categoryId", "required,string", validate.Pattern(`^\d+_\d+$`))
+	schemaValidator.AddRule("name", "required,string", nil)
+	schemaValidator.AddRule("price", "required,numeric", nil)
+	schemaValidator.AddRule("variant", "required", nil)
+	schemaValidator.AddAdditionalProperties("variant", "", nil)
+	schemaValidator.AddMessages("categoryId", map[string]string{"regexp": "Category id must look like 435_12"})
+	schemaValidator.AddMessages("name", map[string]string{"": "Name is invalid"})
+	schemaValidator.AddMessages("variant", map[string]string{"additionalProperties": "Variant has unknown fields"})
+
+	err := schemaValidator.Validate()
+
+	assert.Equal(t, "Category id must look like 435_12; Name is invalid; Field 'price' failed in 'required' rule; Variant has unknown fields", err.Error())
+}
+
+func TestSchemaValidator_Validate_InvalidPattern(t *testing.T) {
+	schemaValidator := getSchemaValidator(`{"name": "abc"}`)
+	schemaValidator.AddRule("name", "required,string", validate.Pattern(`^[a-z`))
+
+	err := schemaValidator.Validate()
+
+	if assert.Error(t, err) {
+		assert.Equal(t, "regexp", err.(validate.ValidationErrors)["name"][0].Rule)
+	}
+}
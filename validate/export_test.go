@@ -0,0 +1,62 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidatePerRule validates request body the way SchemaValidator did before
+// rules were compiled into Schema: body is decoded field by field, rules are
+// added for the request and body is walked once for every rule. It is
+// a baseline of benchmarks.
+func ValidatePerRule(v *validator.Validate, req *http.Request, rules, additionalProperties []FieldRule) error {
+	buffer, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+
+	if json.Valid(buffer) == false {
+		return ErrInvalidJSON
+	}
+
+	var requestBody MapField
+	if err := json.Unmarshal(buffer, &requestBody); err != nil {
+		return err
+	}
+
+	s := &SchemaValidator{
+		validator:   v,
+		requestBody: requestBody,
+		errors:      make(ValidationErrors),
+		context:     context.Background(),
+	}
+
+	for _, rule := range rules {
+		s.AddRule(rule.Field, rule.Rule, rule.Pattern)
+		s.AddMessages(rule.Field, rule.Messages)
+	}
+
+	for _, rule := range additionalProperties {
+		s.AddAdditionalProperties(rule.Field, rule.Rule, rule.Pattern)
+	}
+
+	var values []FieldSchema
+	for _, path := range s.orderedPaths() {
+		s.getValue(s.rules[path].Path, 0, FieldsArray{s.requestBody}, func(field FieldSchema) {
+			values = append(values, field)
+		}, FieldPath{})
+	}
+
+	for _, field := range values {
+		s.validateField(field)
+	}
+
+	// without rule tree, declared paths are looked up in all rules
+	s.validateAdditionalProperties(s.requestBody, "", "")
+
+	return s.result()
+}
@@ -0,0 +1,110 @@
+package validate
+
+import (
+	"encoding/base64"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RuleFormat checks string value with format registered under name passed as
+// parameter of the rule, e.g. format=date.
+const RuleFormat = "format"
+
+// FormatFunc reports whether value is valid in format.
+type FormatFunc func(value string) bool
+
+var (
+	formatsMutex sync.RWMutex
+	formats      = map[string]FormatFunc{
+		"date":      IsDate,
+		"date-time": IsDateTime,
+		"time":      IsTime,
+		"duration":  IsDuration,
+		"byte":      IsBase64,
+		"hostname":  IsHostname,
+	}
+)
+
+// RegisterFormat adds format checked by format rule, which is generated for
+// formats of schema. Built-in formats are replaced by format of the same
+// name. Values of formats which are not registered are accepted.
+func RegisterFormat(name string, fn FormatFunc) {
+	formatsMutex.Lock()
+	defer formatsMutex.Unlock()
+
+	formats[name] = fn
+}
+
+// IsFormat checks string value with format named by parameter of rule. Other
+// values are checked by their type rules.
+func IsFormat(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return true
+	}
+
+	formatsMutex.RLock()
+	fn, ok := formats[fl.Param()]
+	formatsMutex.RUnlock()
+
+	return !ok || fn(fl.Field().String())
+}
+
+// IsDate accepts full-date of RFC 3339, e.g. 2021-05-01.
+func IsDate(value string) bool {
+	_, err := time.Parse("2006-01-02", value)
+
+	return err == nil
+}
+
+// IsDateTime accepts date-time of RFC 3339 with time offset, e.g.
+// 2021-05-01T10:00:00+02:00.
+func IsDateTime(value string) bool {
+	_, err := time.Parse(time.RFC3339Nano, value)
+
+	return err == nil
+}
+
+// IsTime accepts full-time of RFC 3339 with time offset, e.g. 10:00:00Z.
+func IsTime(value string) bool {
+	// fraction of second is parsed also when layout has none
+	_, err := time.Parse("15:04:05Z07:00", value)
+
+	return err == nil
+}
+
+var durationRegexp = regexp.MustCompile(`^P(?:\d+W|(?:\d+Y)?(?:\d+M)?(?:\d+D)?(?:T(?:\d+H)?(?:\d+M)?(?:\d+(?:[.,]\d+)?S)?)?)$`)
+
+// IsDuration accepts duration of ISO 8601, e.g. P1DT12H. At least one
+// component is required.
+func IsDuration(value string) bool {
+	return durationRegexp.MatchString(value) && value != "P" && !strings.HasSuffix(value, "T")
+}
+
+// IsBase64 accepts base64 encoded data with padding.
+func IsBase64(value string) bool {
+	_, err := base64.StdEncoding.DecodeString(value)
+
+	return err == nil
+}
+
+var hostnameLabelRegexp = regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// IsHostname accepts host name of RFC 1123.
+func IsHostname(value string) bool {
+	if value == "" || len(value) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(value, ".") {
+		if !hostnameLabelRegexp.MatchString(label) {
+			return false
+		}
+	}
+
+	return true
+}
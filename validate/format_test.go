@@ -0,0 +1,76 @@
+package validate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/validate"
+)
+
+func TestFormats(t *testing.T) {
+	testData := []struct {
+		format string
+		value  string
+		want   bool
+	}{
+		{"date", "2021-05-01", true},
+		{"date", "2021-02-30", false},
+		{"date", "2021-5-1", false},
+		{"date", "2021-05-01T10:00:00Z", false},
+		{"date-time", "2021-05-01T10:00:00Z", true},
+		{"date-time", "2021-05-01T10:00:00.123+02:00", true},
+		{"date-time", "2021-05-01T10:00:00", false},
+		{"date-time", "2021-05-01", false},
+		{"time", "10:00:00Z", true},
+		{"time", "23:59:59.5-05:30", true},
+		{"time", "10:00:00", false},
+		{"time", "25:00:00Z", false},
+		{"duration", "P1DT12H", true},
+		{"duration", "PT0.5S", true},
+		{"duration", "P2W", true},
+		{"duration", "P", false},
+		{"duration", "P1DT", false},
+		{"duration", "1D", false},
+		{"byte", "c3BlYzJnbw==", true},
+		{"byte", "c3BlYzJnbw", false},
+		{"hostname", "api.example.com", true},
+		{"hostname", "-api.example.com", false},
+		{"hostname", "api..example.com", false},
+		{"hostname", strings.Repeat("a", 64) + ".com", false},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.format+" "+tt.value, func(t *testing.T) {
+			schemaValidator := getSchemaValidator(`{"value": "` + tt.value + `"}`)
+			schemaValidator.AddRule("value", "required,string,format="+tt.format, nil)
+
+			err := schemaValidator.Validate()
+
+			if tt.want {
+				assert.Nil(t, err)
+			} else if assert.Error(t, err) {
+				assert.Equal(t, "Field 'value' failed in 'format' rule, format: "+tt.format, err.Error())
+			}
+		})
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	validate.RegisterFormat("sku", func(value string) bool {
+		return strings.HasPrefix(value, "SKU-")
+	})
+
+	schemaValidator := getSchemaValidator(`{"sku": "ABC-1", "code": "x", "size": 1}`)
+	schemaValidator.AddRule("sku", "required,string,format=sku", nil)
+	// formats which are not registered and formats of other values are accepted
+	schemaValidator.AddRule("code", "required,string,format=unknown", nil)
+	schemaValidator.AddRule("size", "required,integer,format=sku", nil)
+
+	err := schemaValidator.Validate()
+
+	if assert.Error(t, err) {
+		assert.Equal(t, "Field 'sku' failed in 'format' rule, format: sku", err.Error())
+	}
+}
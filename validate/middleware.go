@@ -0,0 +1,298 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Route maps operation to its generated validators. Validate checks request
+// body and ValidateParameters path, query, header and cookie parameters,
+// both are optional.
+type Route struct {
+	Method             string
+	Path               string
+	Validate           ValidateFunc
+	ValidateParameters ValidateFunc
+}
+
+// UnknownRoutePolicy tells middleware what to do with requests not matching
+// any route.
+type UnknownRoutePolicy int
+
+const (
+	// UnknownRouteAllow passes request to next handler
+	UnknownRouteAllow UnknownRoutePolicy = iota
+	// UnknownRouteReject responds with 404 Not Found
+	UnknownRouteReject
+	// UnknownRouteLog logs request and passes it to next handler
+	UnknownRouteLog
+)
+
+// ErrorHandlerFunc writes response for request which failed validation.
+type ErrorHandlerFunc func(w http.ResponseWriter, req *http.Request, err error)
+
+type MiddlewareConfig struct {
+	Validator *validator.Validate
+	Routes    []Route
+	// BasePath is removed from request path before it is matched with routes
+	BasePath     string
+	UnknownRoute UnknownRoutePolicy
+	// Logger is used by UnknownRouteLog policy and to log causes of errors
+	// written by WriteValidationError and WriteProblem, standard logger by
+	// default
+	Logger *log.Logger
+	// ErrorHandler writes 400 Bad Request, WriteValidationError by default
+	ErrorHandler ErrorHandlerFunc
+	// Stream sets limits of request bodies streamed by generated validators,
+	// see WithStream
+	Stream *StreamConfig
+}
+
+type compiledRoute struct {
+	Route
+	pattern *regexp.Regexp
+	// literals is a number of path segments without parameters
+	literals int
+}
+
+// Middleware returns net/http middleware validating requests with validators
+// of route matching request method and path.
+func Middleware(config MiddlewareConfig) func(http.Handler) http.Handler {
+	if config.Logger == nil {
+		config.Logger = log.Default()
+	}
+
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = WriteValidationError
+	}
+
+	routes := make([]compiledRoute, 0, len(config.Routes))
+	for _, route := range config.Routes {
+		routes = append(routes, compileRoute(route))
+	}
+
+	// routes with more static segments are matched first, so "/offers/mine"
+	// has precedence over "/offers/{offerId}"
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].literals > routes[j].literals
+	})
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			route := matchRoute(routes, req, config.BasePath)
+			if route == nil {
+				switch config.UnknownRoute {
+				case UnknownRouteReject:
+					writeJSON(w, http.StatusNotFound, errorResponse{Message: "unknown route"})
+
+					return
+				case UnknownRouteLog:
+					config.Logger.Printf("validate: unknown route %s %s", req.Method, req.URL.Path)
+				}
+
+				next.ServeHTTP(w, req)
+
+				return
+			}
+
+			ctx := req.Context()
+			if config.Stream != nil {
+				ctx = WithStream(ctx, *config.Stream)
+			}
+
+			if err := validateRoute(config.Validator, route, req, ctx); err != nil {
+				// error handler logs with logger of config
+				config.ErrorHandler(w, req.WithContext(context.WithValue(req.Context(), loggerKey{}, config.Logger)), err)
+
+				return
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// validateRoute runs validators of route and joins their errors.
+func validateRoute(v *validator.Validate, route *Route, req *http.Request, ctx context.Context) error {
+	errs := make(ValidationErrors)
+
+	for _, validate := range []ValidateFunc{route.ValidateParameters, route.Validate} {
+		if validate == nil {
+			continue
+		}
+
+		err := validate(v, req, ctx)
+		if err == nil {
+			continue
+		}
+
+		var validationErrors ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			return err
+		}
+
+		errs.merge(validationErrors)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func compileRoute(route Route) compiledRoute {
+	var pattern strings.Builder
+	literals := 0
+
+	for _, segment := range strings.Split(strings.Trim(route.Path, "/"), "/") {
+		pattern.WriteString("/")
+
+		if !strings.Contains(segment, "{") {
+			literals++
+		}
+
+		for segment != "" {
+			start := strings.Index(segment, "{")
+			end := strings.Index(segment, "}")
+			if start < 0 || end < start {
+				pattern.WriteString(regexp.QuoteMeta(segment))
+
+				break
+			}
+
+			pattern.WriteString(regexp.QuoteMeta(segment[:start]))
+			pattern.WriteString("[^/]+")
+			segment = segment[end+1:]
+		}
+	}
+
+	return compiledRoute{
+		Route:    route,
+		pattern:  regexp.MustCompile("^" + pattern.String() + "/?$"),
+		literals: literals,
+	}
+}
+
+func matchRoute(routes []compiledRoute, req *http.Request, basePath string) *Route {
+	path := req.URL.EscapedPath()
+	if basePath != "" {
+		if !strings.HasPrefix(path, basePath) {
+			return nil
+		}
+
+		path = strings.TrimPrefix(path, basePath)
+		if path == "" {
+			path = "/"
+		}
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return nil
+	}
+
+	for i := range routes {
+		if strings.EqualFold(routes[i].Method, req.Method) && routes[i].pattern.MatchString(path) {
+			return &routes[i].Route
+		}
+	}
+
+	return nil
+}
+
+type errorResponse struct {
+	Message string           `json:"message"`
+	Errors  []fieldErrorJSON `json:"errors,omitempty"`
+}
+
+type fieldErrorJSON struct {
+	Field    string      `json:"field"`
+	Rule     string      `json:"rule"`
+	Value    interface{} `json:"value"`
+	Accepted string      `json:"accepted,omitempty"`
+	Message  string      `json:"message"`
+}
+
+// WriteValidationError responds with 400 Bad Request and JSON body listing
+// failed fields in order of ValidationErrors.Sorted. Other errors are logged
+// and responded with fixed message of their status.
+func WriteValidationError(w http.ResponseWriter, req *http.Request, err error) {
+	response := errorResponse{Message: "request validation failed"}
+
+	var validationErrors ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		status := errorStatus(err)
+		logError(req, err)
+
+		response.Message = errorMessage(status)
+		writeJSON(w, status, response)
+
+		return
+	}
+
+	for _, fieldError := range validationErrors.Sorted() {
+		response.Errors = append(response.Errors, fieldErrorJSON{
+			Field:    fieldError.Field,
+			Rule:     fieldError.Rule,
+			Value:    fieldError.Value,
+			Accepted: fieldError.Accepted,
+			Message:  fieldError.Error(),
+		})
+	}
+
+	writeJSON(w, http.StatusBadRequest, response)
+}
+
+// errorStatus returns status of response to request which failed validation
+// with error other than ValidationErrors.
+func errorStatus(err error) int {
+	if errors.Is(err, ErrBodyTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	return http.StatusBadRequest
+}
+
+// errorMessage returns message of response with status, errors other than
+// ValidationErrors are not written as they may expose internals.
+func errorMessage(status int) string {
+	if status == http.StatusRequestEntityTooLarge {
+		return "request body too large"
+	}
+
+	return "invalid request"
+}
+
+type loggerKey struct{}
+
+// logError logs cause of response written with errorMessage with logger of
+// Middleware, standard logger is used when request was not passed by it.
+func logError(req *http.Request, err error) {
+	if req == nil {
+		log.Printf("validate: %v", err)
+
+		return
+	}
+
+	logger, _ := req.Context().Value(loggerKey{}).(*log.Logger)
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	logger.Printf("validate: %s %s: %v", req.Method, req.URL.Path, err)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,132 @@
+package validate_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/validate"
+)
+
+func validateOffer(v *validator.Validate, req *http.Request, ctx context.Context) error {
+	schemaValidator, err := validate.NewSchemaValidator(v, req, ctx)
+	if err != nil {
+		return err
+	}
+
+	schemaValidator.AddRule("name", "required,string", nil)
+
+	return schemaValidator.Validate()
+}
+
+func validateOfferParameters(v *validator.Validate, req *http.Request, ctx context.Context) error {
+	parameterValidator := validate.NewParameterValidator(v, req, ctx, "/offers/{offerId}")
+	parameterValidator.AddRule("offerId", validate.InPath, "required,integer", "", nil)
+
+	return parameterValidator.Validate()
+}
+
+var testRoutes = []validate.Route{
+	{Method: http.MethodPut, Path: "/offers/{offerId}", Validate: validateOffer, ValidateParameters: validateOfferParameters},
+	{Method: http.MethodPut, Path: "/offers/mine"},
+}
+
+func TestMiddleware(t *testing.T) {
+	testData := []struct {
+		method string
+		target string
+		body   string
+		policy validate.UnknownRoutePolicy
+		status int
+		fields []string
+		// log is written to logger of config
+		log string
+	}{
+		{http.MethodPut, "/api/offers/12", `{"name": "foo"}`, validate.UnknownRouteAllow, http.StatusOK, nil, ""},
+		{http.MethodPut, "/api/offers/abc", `{}`, validate.UnknownRouteAllow, http.StatusBadRequest, []string{"offerId", "name"}, ""},
+		{http.MethodPut, "/api/offers/12", `{"name": `, validate.UnknownRouteAllow, http.StatusBadRequest, nil, "validate: PUT /api/offers/12: invalid json\n"},
+		{http.MethodPut, "/api/offers/mine", `{}`, validate.UnknownRouteAllow, http.StatusOK, nil, ""},
+		{http.MethodGet, "/api/offers/12", ``, validate.UnknownRouteAllow, http.StatusOK, nil, ""},
+		{http.MethodGet, "/api/offers/12", ``, validate.UnknownRouteReject, http.StatusNotFound, nil, ""},
+		{http.MethodPut, "/offers/12", `{}`, validate.UnknownRouteReject, http.StatusNotFound, nil, ""},
+		{http.MethodGet, "/api/offers/12", ``, validate.UnknownRouteLog, http.StatusOK, nil, "validate: unknown route GET /api/offers/12\n"},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			var logs bytes.Buffer
+
+			middleware := validate.Middleware(validate.MiddlewareConfig{
+				Validator:    NewValidator(),
+				Routes:       testRoutes,
+				BasePath:     "/api",
+				UnknownRoute: tt.policy,
+				Logger:       log.New(&logs, "", 0),
+			})
+
+			handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+
+			assert.Equal(t, tt.log, logs.String())
+
+			if tt.fields == nil {
+				return
+			}
+
+			var response struct {
+				Message string
+				Errors  []struct{ Field, Rule string }
+			}
+			_ = json.Unmarshal(rec.Body.Bytes(), &response)
+
+			var fields []string
+			for _, fieldError := range response.Errors {
+				fields = append(fields, fieldError.Field)
+			}
+
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			assert.Equal(t, "request validation failed", response.Message)
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestWriteValidationError(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/offers/12", nil)
+	rec := httptest.NewRecorder()
+	validate.WriteValidationError(rec, req, fmt.Errorf("read /var/run/body: %w", validate.ErrBodyTooLarge))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.JSONEq(t, `{"message": "request body too large"}`, rec.Body.String())
+	assert.Contains(t, logs.String(), "validate: PUT /api/offers/12: read /var/run/body: request body too large")
+
+	rec = httptest.NewRecorder()
+	validate.WriteValidationError(rec, req, validate.ValidationErrors{
+		"name": {{Field: "name", Rule: "required"}},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "request validation failed", "errors": [`+
+		`{"field": "name", "rule": "required", "value": null, "message": "Field 'name' failed in 'required' rule"}]}`, rec.Body.String())
+}
@@ -0,0 +1,32 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidateFunc is a signature of generated validators.
+type ValidateFunc func(v *validator.Validate, req *http.Request, ctx context.Context) error
+
+// Unmarshal validates request and decodes its body into model. Body is
+// restored, so it can be read again by next handlers.
+func Unmarshal(v *validator.Validate, req *http.Request, ctx context.Context, validate ValidateFunc, model interface{}) error {
+	if err := validate(v, req, ctx); err != nil {
+		return err
+	}
+
+	buffer, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+
+	// restore body in request
+	req.Body = io.NopCloser(bytes.NewBuffer(buffer))
+
+	return json.Unmarshal(buffer, model)
+}
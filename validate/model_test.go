@@ -0,0 +1,46 @@
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/validate"
+)
+
+type offer struct {
+	Name string `json:"name"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	errInvalid := errors.New("invalid")
+
+	testData := []struct {
+		validate validate.ValidateFunc
+		want     error
+		model    offer
+	}{
+		{func(v *validator.Validate, req *http.Request, ctx context.Context) error { return nil }, nil, offer{Name: "foo"}},
+		{func(v *validator.Validate, req *http.Request, ctx context.Context) error { return errInvalid }, errInvalid, offer{}},
+	}
+
+	for _, tt := range testData {
+		req, _ := http.NewRequest(http.MethodPost, "/offers", strings.NewReader(`{"name": "foo"}`))
+
+		model := offer{}
+		err := validate.Unmarshal(NewValidator(), req, context.Background(), tt.validate, &model)
+		assert.Equal(t, tt.want, err)
+		assert.Equal(t, tt.model, model)
+
+		if err == nil {
+			body, _ := io.ReadAll(req.Body)
+			assert.Equal(t, `{"name": "foo"}`, string(body))
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package validate
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// maxExactFloat is the largest integer which float64 holds without losing
+// precision.
+const maxExactFloat = 1 << 53
+
+// numericParams are rules comparing value with their parameter.
+var numericParams = []string{"min=", "max=", "gt=", "gte=", "lt=", "lte=", "eq=", "ne="}
+
+// numberValue converts number decoded from request body for validator.
+// Numbers are float64 unless they are integers too large for it, those keep
+// precision as int64 or uint64 when all parameters of rules are integers of
+// the same kind, validator cannot compare them otherwise.
+func numberValue(number json.Number, rules Rules) interface{} {
+	text := number.String()
+
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		if (i > maxExactFloat || i < -maxExactFloat) && paramsParse(rules, func(param string) error {
+			_, err := strconv.ParseInt(param, 0, 64)
+			return err
+		}) {
+			return i
+		}
+
+		return float64(i)
+	}
+
+	if u, err := strconv.ParseUint(text, 10, 64); err == nil && paramsParse(rules, func(param string) error {
+		_, err := strconv.ParseUint(param, 0, 64)
+		return err
+	}) {
+		return u
+	}
+
+	f, _ := strconv.ParseFloat(text, 64)
+
+	return f
+}
+
+// plainValue converts number of request body reported in error, so errors
+// hold the same values as before numbers were decoded with their text.
+func plainValue(value interface{}) interface{} {
+	if number, ok := value.(json.Number); ok {
+		return numberValue(number, nil)
+	}
+
+	return value
+}
+
+func paramsParse(rules Rules, parse func(param string) error) bool {
+	for _, rule := range rules {
+		for _, prefix := range numericParams {
+			if strings.HasPrefix(rule, prefix) && parse(strings.TrimPrefix(rule, prefix)) != nil {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// IsInteger accepts numbers without fractional part.
+func IsInteger(fl validator.FieldLevel) bool {
+	switch fl.Field().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Float32, reflect.Float64:
+		v := fl.Field().Float()
+
+		return v == math.Trunc(v) && !math.IsInf(v, 0)
+	}
+
+	return false
+}
+
+// IsInt32 accepts numbers in range of int32 format.
+func IsInt32(fl validator.FieldLevel) bool {
+	return inRange(fl.Field(), math.MinInt32, math.MaxInt32)
+}
+
+// IsInt64 accepts numbers in range of int64 format.
+func IsInt64(fl validator.FieldLevel) bool {
+	return inRange(fl.Field(), math.MinInt64, math.MaxInt64)
+}
+
+// IsFloat accepts numbers in range of float format.
+func IsFloat(fl validator.FieldLevel) bool {
+	return numberKind(fl.Field()) && math.Abs(toFloat(fl.Field())) <= math.MaxFloat32
+}
+
+// IsDouble accepts numbers in range of double format, numbers too large for
+// float64 are decoded as infinity.
+func IsDouble(fl validator.FieldLevel) bool {
+	return numberKind(fl.Field()) && !math.IsInf(toFloat(fl.Field()), 0)
+}
+
+func numberKind(field reflect.Value) bool {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+func toFloat(field reflect.Value) float64 {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(field.Uint())
+	}
+
+	return field.Float()
+}
+
+func inRange(field reflect.Value, min, max int64) bool {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int() >= min && field.Int() <= max
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return field.Uint() <= uint64(max)
+	case reflect.Float32, reflect.Float64:
+		// float64(max) of int64 is rounded up to 2^63
+		return field.Float() >= float64(min) && field.Float() < float64(max)+1
+	}
+
+	return false
+}
+
+// IsMultipleOf accepts numbers which divided by parameter give an integer.
+// Numbers are compared as decimals, so 0.07 is a multiple of 0.01.
+func IsMultipleOf(fl validator.FieldLevel) bool {
+	divisor, ok := new(big.Rat).SetString(fl.Param())
+	if !ok || divisor.Sign() == 0 {
+		return false
+	}
+
+	value := new(big.Rat)
+	field := fl.Field()
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt64(field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value.SetUint64(field.Uint())
+	case reflect.Float32, reflect.Float64:
+		// shortest decimal form of float is the number written in request,
+		// not its binary approximation
+		if _, ok := value.SetString(strconv.FormatFloat(field.Float(), 'g', -1, 64)); !ok {
+			return false
+		}
+	default:
+		return false
+	}
+
+	return value.Quo(value, divisor).IsInt()
+}
@@ -0,0 +1,343 @@
+package validate
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	InPath   = "path"
+	InQuery  = "query"
+	InHeader = "header"
+	InCookie = "cookie"
+)
+
+// PathParamFunc returns value of path parameter with given name for request
+// matched by path template, e.g. "/jobs/{jobId}".
+type PathParamFunc func(req *http.Request, template, name string) (string, bool)
+
+// PathParam is used to extract path parameters. It can be replaced with
+// router specific implementation, e.g. one based on chi.URLParam.
+var PathParam PathParamFunc = TemplatePathParam
+
+type ParameterRule struct {
+	Name      string
+	In        string
+	Rules     Rules
+	ItemRules Rules
+	Pattern   *string
+	// matcher is compiled Pattern
+	matcher *patternMatcher
+}
+
+type ParameterValidator struct {
+	validator  *validator.Validate
+	request    *http.Request
+	template   string
+	rules      []ParameterRule
+	errors     ValidationErrors
+	context    context.Context
+	translator ut.Translator
+	// messages are spec-authored messages of rules keyed by parameter name
+	messages map[string]map[string]string
+}
+
+func NewParameterValidator(v *validator.Validate, req *http.Request, ctx context.Context, template string) *ParameterValidator {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return &ParameterValidator{
+		validator:  v,
+		request:    req,
+		template:   template,
+		errors:     make(ValidationErrors),
+		context:    ctx,
+		translator: requestTranslator(req),
+	}
+}
+
+// AddRule adds rule for parameter. Item rule is set only for array
+// parameters and is checked against every item.
+func (p *ParameterValidator) AddRule(name, in, rule, itemRule string, pattern *string) {
+	parameterRule := ParameterRule{
+		Name:    name,
+		In:      in,
+		Rules:   strings.Split(rule, ","),
+		Pattern: pattern,
+		matcher: newPatternMatcher(pattern),
+	}
+
+	if itemRule != "" {
+		parameterRule.ItemRules = strings.Split(itemRule, ",")
+	}
+
+	p.rules = append(p.rules, parameterRule)
+}
+
+// AddMessages sets messages of failed rules of parameter. They are keyed by
+// rule name, message keyed by empty string is used for any rule.
+func (p *ParameterValidator) AddMessages(name string, messages map[string]string) {
+	if len(messages) == 0 {
+		return
+	}
+
+	if p.messages == nil {
+		p.messages = make(map[string]map[string]string)
+	}
+
+	p.messages[name] = messages
+}
+
+func (p *ParameterValidator) Validate() error {
+	for _, rule := range p.rules {
+		values, ok := p.values(rule.Name, rule.In)
+
+		if rule.ItemRules != nil {
+			p.validateArray(rule, values, ok)
+
+			continue
+		}
+
+		raw := ""
+		if ok && len(values) > 0 {
+			raw = values[0]
+		}
+
+		p.validateValue(rule.Name, rule.Rules, rule.matcher, raw, ok)
+	}
+
+	if len(p.errors) > 0 {
+		p.errors.setOrder(func(fieldName string) (int, bool) {
+			name := parameterName(fieldName)
+
+			for i, rule := range p.rules {
+				if rule.Name == name {
+					return i, true
+				}
+			}
+
+			return 0, false
+		})
+
+		p.errors.setMessages(func(fieldName, rule string) (string, bool) {
+			return ruleMessage(p.messages[parameterName(fieldName)], rule)
+		})
+
+		if p.translator != nil {
+			p.errors.translate(p.translator)
+		}
+
+		return p.errors
+	}
+
+	return nil
+}
+
+func (p *ParameterValidator) validateArray(rule ParameterRule, values []string, ok bool) {
+	// non exploded arrays are sent as comma separated list
+	if len(values) == 1 {
+		values = strings.Split(values[0], ",")
+	}
+
+	var items []interface{}
+	if ok {
+		items = make([]interface{}, 0, len(values))
+		for _, value := range values {
+			items = append(items, value)
+		}
+	}
+
+	var value interface{}
+	if items != nil {
+		value = items
+	}
+
+	err := p.validator.VarCtx(p.context, value, rule.Rules.String())
+	p.errors.try(rule.Name, err)
+
+	if rule.Rules.Has(RuleUniqueItems) {
+		p.errors.unique(rule.Name, items)
+	}
+
+	if !ok {
+		return
+	}
+
+	for i, item := range values {
+		p.validateValue(rule.Name+"["+strconv.Itoa(i)+"]", rule.ItemRules, rule.matcher, item, true)
+	}
+}
+
+func (p *ParameterValidator) validateValue(fieldName string, rules Rules, pattern *patternMatcher, raw string, ok bool) {
+	if rules.Required() {
+		if !ok || raw == "" {
+			p.errors[fieldName] = append(p.errors[fieldName], FieldError{
+				Field: fieldName,
+				Rule:  "required",
+			})
+
+			return
+		}
+
+		// present value is checked by other rules, required rule of validator
+		// would reject zero values like 0 or false
+		rules = rules.Without("required")
+	}
+
+	value, failedRule := coerceParameter(raw, ok, rules)
+	if failedRule != "" {
+		p.errors[fieldName] = append(p.errors[fieldName], FieldError{
+			Field: fieldName,
+			Rule:  failedRule,
+			Value: raw,
+		})
+
+		return
+	}
+
+	var err error
+	switch value.(type) {
+	case bool:
+		err = p.validator.VarCtx(p.context, value, rules.ForBool().String())
+	default:
+		err = p.validator.VarCtx(p.context, value, rules.String())
+	}
+	p.errors.try(fieldName, err)
+
+	if err == nil && pattern != nil && raw != "" {
+		if err := validatePattern(fieldName, pattern, raw); err != nil {
+			p.errors[fieldName] = append(p.errors[fieldName], *err)
+		}
+	}
+}
+
+// parameterName returns name of parameter without index of array item.
+func parameterName(fieldName string) string {
+	if position := strings.Index(fieldName, "["); position >= 0 {
+		return fieldName[:position]
+	}
+
+	return fieldName
+}
+
+// coerceParameter converts raw parameter value to type declared by rules.
+// When value cannot be converted, name of type rule is returned.
+func coerceParameter(raw string, ok bool, rules Rules) (value interface{}, failedRule string) {
+	switch {
+	case rules.Has("integer"):
+		if !ok || raw == "" {
+			return nil, ""
+		}
+
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, "integer"
+		}
+
+		return v, ""
+	case rules.Has("numeric"):
+		if !ok || raw == "" {
+			return nil, ""
+		}
+
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, "numeric"
+		}
+
+		return v, ""
+	case rules.Has("boolean"):
+		if !ok || raw == "" {
+			return nil, ""
+		}
+
+		switch raw {
+		case "true":
+			return true, ""
+		case "false":
+			return false, ""
+		}
+
+		return nil, "boolean"
+	}
+
+	if !ok {
+		return nil, ""
+	}
+
+	return raw, ""
+}
+
+func (p *ParameterValidator) values(name, in string) ([]string, bool) {
+	switch in {
+	case InQuery:
+		values, ok := p.request.URL.Query()[name]
+
+		return values, ok
+	case InHeader:
+		values := p.request.Header.Values(name)
+
+		return values, len(values) > 0
+	case InCookie:
+		cookie, err := p.request.Cookie(name)
+		if err != nil {
+			return nil, false
+		}
+
+		return []string{cookie.Value}, true
+	case InPath:
+		value, ok := PathParam(p.request, p.template, name)
+		if !ok {
+			return nil, false
+		}
+
+		return []string{value}, true
+	}
+
+	return nil, false
+}
+
+// TemplatePathParam extracts path parameter by matching request path with
+// path template. Template is matched against the end of the path, so server
+// base paths like "/v2" are skipped.
+func TemplatePathParam(req *http.Request, template, name string) (string, bool) {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(req.URL.EscapedPath(), "/"), "/")
+
+	if len(pathSegments) < len(templateSegments) {
+		return "", false
+	}
+	pathSegments = pathSegments[len(pathSegments)-len(templateSegments):]
+
+	placeholder := "{" + name + "}"
+	for i, segment := range templateSegments {
+		position := strings.Index(segment, placeholder)
+		if position < 0 {
+			continue
+		}
+
+		prefix := segment[:position]
+		suffix := segment[position+len(placeholder):]
+		value := pathSegments[i]
+
+		if !strings.HasPrefix(value, prefix) || !strings.HasSuffix(value, suffix) || len(value) < len(prefix)+len(suffix) {
+			return "", false
+		}
+
+		value, err := url.PathUnescape(value[len(prefix) : len(value)-len(suffix)])
+		if err != nil {
+			return "", false
+		}
+
+		return value, true
+	}
+
+	return "", false
+}
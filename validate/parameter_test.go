@@ -0,0 +1,101 @@
+package validate_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/validate"
+)
+
+func getParameterValidator(target, template string) *validate.ParameterValidator {
+	req, _ := http.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("X-Request-Id", "abc")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "12"})
+
+	return validate.NewParameterValidator(NewValidator(), req, context.Background(), template)
+}
+
+func TestParameterValidator_Validate(t *testing.T) {
+	testData := []struct {
+		target   string
+		name     string
+		in       string
+		rules    string
+		itemRule string
+		pattern  *string
+		want     error
+	}{
+		{"/offers?page=2", "page", validate.InQuery, "omitempty,integer,min=1,max=5", "", nil, nil},
+		{"/offers", "page", validate.InQuery, "omitempty,integer,min=1,max=5", "", nil, nil},
+		{"/offers", "page", validate.InQuery, "required,integer", "", nil, getExpectedError("page", "required", nil, "")},
+		{"/offers?page=abc", "page", validate.InQuery, "omitempty,integer", "", nil, getExpectedError("page", "integer", "abc", "")},
+		{"/offers?page=6", "page", validate.InQuery, "omitempty,integer,min=1,max=5", "", nil, getExpectedError("page", "max", int64(6), "5")},
+		{"/offers?enabled=yes", "enabled", validate.InQuery, "omitempty,boolean", "", nil, getExpectedError("enabled", "boolean", "yes", "")},
+		{"/offers?enabled=false", "enabled", validate.InQuery, "omitempty,boolean", "", nil, nil},
+		{"/offers?enabled=false", "enabled", validate.InQuery, "required,boolean", "", nil, nil},
+		{"/offers?page=", "page", validate.InQuery, "required,integer", "", nil, getExpectedError("page", "required", nil, "")},
+		{"/offers?code=pln", "code", validate.InQuery, "omitempty,string", "", validate.Pattern(`^[a-z]{2}$`), getExpectedError("code", "regexp", "pln", `^[a-z]{2}$`)},
+		{"/offers?ids=1,2", "ids", validate.InQuery, "required", "omitempty,integer,max=1", nil, getExpectedError("ids[1]", "max", int64(2), "1")},
+		{"/offers?ids=1&ids=x", "ids", validate.InQuery, "required", "omitempty,integer", nil, getExpectedError("ids[1]", "integer", "x", "")},
+		{"/offers", "ids", validate.InQuery, "required", "omitempty,integer", nil, getExpectedError("ids", "required", nil, "")},
+		{"/offers?ids=1,2,1", "ids", validate.InQuery, "required,uniqueItems", "omitempty,integer", nil, getExpectedError("ids", "uniqueItems", "1", "0, 2")},
+		{"/offers?ids=1,2,3", "ids", validate.InQuery, "required,max=2", "omitempty,integer", nil, getExpectedError("ids", "max", []interface{}{"1", "2", "3"}, "2")},
+		{"/offers", "X-Request-Id", validate.InHeader, "required,string,max=2", "", nil, getExpectedError("X-Request-Id", "max", "abc", "2")},
+		{"/offers", "session", validate.InCookie, "required,integer,min=13", "", nil, getExpectedError("session", "min", int64(12), "13")},
+		{"/v2/jobs/12", "jobId", validate.InPath, "required,integer,min=1", "", nil, nil},
+		{"/v2/jobs/0", "jobId", validate.InPath, "required,integer,min=1", "", nil, getExpectedError("jobId", "min", int64(0), "1")},
+		{"/v2/jobs/a%20b", "jobId", validate.InPath, "required,string,max=2", "", nil, getExpectedError("jobId", "max", "a b", "2")},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.target+" "+tt.name, func(t *testing.T) {
+			parameterValidator := getParameterValidator(tt.target, "/jobs/{jobId}")
+			parameterValidator.AddRule(tt.name, tt.in, tt.rules, tt.itemRule, tt.pattern)
+			err := parameterValidator.Validate()
+
+			if tt.want == nil {
+				assert.Nil(t, err)
+
+				return
+			}
+
+			for field, expected := range tt.want.(validate.ValidationErrors) {
+				fieldErrors := err.(validate.ValidationErrors)[field]
+				if assert.Len(t, fieldErrors, 1) {
+					assert.Equal(t, expected[0].Rule, fieldErrors[0].Rule)
+					assert.Equal(t, expected[0].Value, fieldErrors[0].Value)
+					assert.Equal(t, expected[0].Accepted, fieldErrors[0].Accepted)
+				}
+			}
+		})
+	}
+}
+
+func TestParameterValidator_Validate_Messages(t *testing.T) {
+	parameterValidator := getParameterValidator("/offers?ids=1,x&page=x", "")
+	parameterValidator.AddRule("ids", validate.InQuery, "required", "omitempty,integer", nil)
+	parameterValidator.AddRule("page", validate.InQuery, "omitempty,integer,min=1", "", nil)
+	parameterValidator.AddMessages("ids", map[string]string{"integer": "Ids must be numbers"})
+	parameterValidator.AddMessages("page", map[string]string{"": "Page is invalid"})
+
+	err := parameterValidator.Validate()
+
+	assert.Equal(t, "Ids must be numbers; Page is invalid", err.Error())
+}
+
+func TestTemplatePathParam(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "/v2/files/report.json", nil)
+
+	value, ok := validate.TemplatePathParam(req, "/files/{name}.json", "name")
+	assert.True(t, ok)
+	assert.Equal(t, "report", value)
+
+	_, ok = validate.TemplatePathParam(req, "/files/{name}.json", "id")
+	assert.False(t, ok)
+
+	_, ok = validate.TemplatePathParam(req, "/a/b/c/d/{name}", "name")
+	assert.False(t, ok)
+}
@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"regexp"
+	"sync"
+)
+
+// PatternMatcher matches values with compiled pattern.
+type PatternMatcher interface {
+	MatchString(s string) bool
+}
+
+// PatternEngineFunc compiles pattern of schema.
+type PatternEngineFunc func(pattern string) (PatternMatcher, error)
+
+// PatternEngine compiles patterns of rules, RE2 is used by default. It can be
+// replaced with engine implementing full ECMA-262 semantics, e.g. one based on
+// github.com/dlclark/regexp2, for validators generated with ECMA patterns.
+// Pattern of each rule is compiled once, when it is first matched.
+var PatternEngine PatternEngineFunc = RE2Pattern
+
+// RE2Pattern compiles pattern with regexp package.
+func RE2Pattern(pattern string) (PatternMatcher, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return re, nil
+}
+
+// patternMatcher is a pattern of rule compiled by PatternEngine when it is
+// first matched, so engine set in main applies to schemas of package
+// variables. Compiled pattern and compile error are kept with the rule, so
+// pattern is compiled once.
+type patternMatcher struct {
+	pattern string
+	once    sync.Once
+	matcher PatternMatcher
+	err     error
+}
+
+// newPatternMatcher returns matcher of pattern, it is nil for empty pattern.
+func newPatternMatcher(pattern *string) *patternMatcher {
+	if pattern == nil || *pattern == "" {
+		return nil
+	}
+
+	return &patternMatcher{pattern: *pattern}
+}
+
+// MatchString reports whether value matches pattern. Value never matches
+// pattern which cannot be compiled.
+func (p *patternMatcher) MatchString(value string) bool {
+	p.once.Do(func() {
+		p.matcher, p.err = PatternEngine(p.pattern)
+	})
+
+	return p.err == nil && p.matcher.MatchString(value)
+}
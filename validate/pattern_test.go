@@ -0,0 +1,103 @@
+package validate_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/validate"
+)
+
+type prefixMatcher string
+
+func (m prefixMatcher) MatchString(s string) bool {
+	return strings.HasPrefix(s, string(m))
+}
+
+func TestPatternEngine(t *testing.T) {
+	validate.PatternEngine = func(pattern string) (validate.PatternMatcher, error) {
+		// lookahead of ECMA-262 is not supported by RE2
+		return prefixMatcher(strings.TrimSuffix(strings.TrimPrefix(pattern, "^(?="), ")")), nil
+	}
+	defer func() {
+		validate.PatternEngine = validate.RE2Pattern
+	}()
+
+	schemaValidator := getSchemaValidator(`{"code": "abc", "name": "xyz"}`)
+	schemaValidator.AddRule("code", "required,string", validate.Pattern(`^(?=ab)`))
+	schemaValidator.AddRule("name", "required,string", validate.Pattern(`^(?=ab)`))
+
+	err := schemaValidator.Validate()
+
+	if assert.Error(t, err) {
+		assert.Equal(t, "Field 'name' failed in 'regexp' rule, available values: ^(?=ab)", err.Error())
+	}
+}
+
+func TestPatternEngine_Schema(t *testing.T) {
+	// schema of package variable is created before engine is set in main
+	schema := validate.NewSchema([]validate.FieldRule{
+		{Field: "code", Rule: "required,string", Pattern: validate.Pattern(`^(?=ab)`)},
+	}, nil, nil)
+
+	validate.PatternEngine = func(pattern string) (validate.PatternMatcher, error) {
+		return prefixMatcher(strings.TrimSuffix(strings.TrimPrefix(pattern, "^(?="), ")")), nil
+	}
+	defer func() {
+		validate.PatternEngine = validate.RE2Pattern
+	}()
+
+	req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"code": "abc"}`))
+	assert.Nil(t, schema.Validate(NewValidator(), req, nil))
+
+	req, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"code": "xyz"}`))
+	err := schema.Validate(NewValidator(), req, nil)
+
+	if assert.Error(t, err) {
+		assert.Equal(t, "Field 'code' failed in 'regexp' rule, available values: ^(?=ab)", err.Error())
+	}
+}
+
+func TestPatternEngine_CompiledOnce(t *testing.T) {
+	var compiled []string
+	engine := func(prefix string) validate.PatternEngineFunc {
+		return func(pattern string) (validate.PatternMatcher, error) {
+			compiled = append(compiled, prefix+pattern)
+			if pattern == "(" {
+				return nil, errors.New("missing closing )")
+			}
+
+			return prefixMatcher(prefix), nil
+		}
+	}
+	defer func() {
+		validate.PatternEngine = validate.RE2Pattern
+	}()
+
+	rules := []validate.FieldRule{
+		{Field: "code", Rule: "required,string", Pattern: validate.Pattern(`^code`)},
+		{Field: "name", Rule: "omitempty,string", Pattern: validate.Pattern(`(`)},
+	}
+
+	// engines made by the same function do not share compiled patterns
+	for _, prefix := range []string{"a", "b"} {
+		validate.PatternEngine = engine(prefix)
+		schema := validate.NewSchema(rules, nil, nil)
+
+		for i := 0; i < 2; i++ {
+			req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"code": "`+prefix+`1", "name": "x"}`))
+			err := schema.Validate(NewValidator(), req, nil)
+
+			if assert.Error(t, err) {
+				assert.Equal(t, "Field 'name' failed in 'regexp' rule, available values: (", err.Error())
+			}
+		}
+	}
+
+	// compiled patterns and compile errors are kept by rules
+	assert.Equal(t, []string{"a^code", "a(", "b^code", "b("}, compiled)
+}
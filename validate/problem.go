@@ -0,0 +1,122 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ContentTypeProblem is a media type of RFC 7807 problem details.
+const ContentTypeProblem = "application/problem+json"
+
+// ProblemTypeFunc returns type URI of error failed in given rule. Empty
+// string leaves type out.
+type ProblemTypeFunc func(rule string) string
+
+// ProblemType is used to set type of every problem error, errors have no
+// type by default.
+var ProblemType ProblemTypeFunc = func(rule string) string {
+	return ""
+}
+
+// Problem is an RFC 7807 problem details document with failed fields listed
+// in errors extension member.
+type Problem struct {
+	Type     string         `json:"type,omitempty"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Errors   []ProblemError `json:"errors,omitempty"`
+}
+
+type ProblemError struct {
+	Type string `json:"type,omitempty"`
+	// Pointer is a JSON Pointer to the field, e.g. /variants/0/price
+	Pointer  string      `json:"pointer"`
+	Rule     string      `json:"rule"`
+	Value    interface{} `json:"value"`
+	Accepted string      `json:"accepted,omitempty"`
+	Detail   string      `json:"detail"`
+}
+
+// NewProblem returns problem details of validation errors. Errors are in
+// order of ValidationErrors.Sorted.
+func NewProblem(errs ValidationErrors) *Problem {
+	problem := &Problem{
+		Title:  "Request validation failed",
+		Status: http.StatusBadRequest,
+	}
+
+	for _, fieldError := range errs.Sorted() {
+		problem.Errors = append(problem.Errors, ProblemError{
+			Type:     ProblemType(fieldError.Rule),
+			Pointer:  JSONPointer(fieldError.Field),
+			Rule:     fieldError.Rule,
+			Value:    fieldError.Value,
+			Accepted: fieldError.Accepted,
+			Detail:   fieldError.Error(),
+		})
+	}
+
+	return problem
+}
+
+// WriteProblem responds with 400 Bad Request and problem details document.
+// It can be used as ErrorHandler of Middleware.
+func WriteProblem(w http.ResponseWriter, req *http.Request, err error) {
+	var problem *Problem
+
+	var validationErrors ValidationErrors
+	if errors.As(err, &validationErrors) {
+		problem = NewProblem(validationErrors)
+	} else {
+		status := errorStatus(err)
+		logError(req, err)
+
+		problem = &Problem{
+			Title:  http.StatusText(status),
+			Status: status,
+			Detail: errorMessage(status),
+		}
+	}
+
+	if req != nil {
+		problem.Instance = req.URL.RequestURI()
+	}
+
+	w.Header().Set("Content-Type", ContentTypeProblem)
+	w.WriteHeader(problem.Status)
+
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// JSONPointer converts field name like "variants[0].price" into JSON Pointer
+// "/variants/0/price".
+func JSONPointer(field string) string {
+	if field == "" {
+		return ""
+	}
+
+	var pointer strings.Builder
+	for _, name := range strings.Split(field, ".") {
+		var indexes []string
+		if position := strings.Index(name, "["); position >= 0 && strings.HasSuffix(name, "]") {
+			indexes = strings.Split(name[position+1:len(name)-1], "][")
+			name = name[:position]
+		}
+
+		if name != "" {
+			pointer.WriteString("/" + pointerEscaper.Replace(name))
+		}
+
+		for _, index := range indexes {
+			pointer.WriteString("/" + index)
+		}
+	}
+
+	return pointer.String()
+}
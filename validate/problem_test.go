@@ -0,0 +1,90 @@
+package validate_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/validate"
+)
+
+func TestJSONPointer(t *testing.T) {
+	testData := map[string]string{
+		"":                       "",
+		"name":                   "/name",
+		"variants[0].price":      "/variants/0/price",
+		"variants[0].tags[1]":    "/variants/0/tags/1",
+		"ids[2]":                 "/ids/2",
+		"a/b.c~d":                "/a~1b/c~0d",
+		"matrix[0][1]":           "/matrix/0/1",
+		"variants[0].media.urls": "/variants/0/media/urls",
+	}
+
+	for field, want := range testData {
+		assert.Equal(t, want, validate.JSONPointer(field), field)
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	validate.ProblemType = func(rule string) string {
+		return "https://example.com/problems/" + rule
+	}
+	defer func() {
+		validate.ProblemType = func(rule string) string { return "" }
+	}()
+
+	errs := validate.ValidationErrors{
+		"variants[0].price": {{Field: "variants[0].price", Rule: "max", Value: float64(12), Accepted: "10"}},
+		"name":              {{Field: "name", Rule: "required"}},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/offers?dry=1", nil)
+	rec := httptest.NewRecorder()
+	validate.WriteProblem(rec, req, errs)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, validate.ContentTypeProblem, rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"title": "Request validation failed",
+		"status": 400,
+		"instance": "/offers?dry=1",
+		"errors": [
+			{
+				"type": "https://example.com/problems/required",
+				"pointer": "/name",
+				"rule": "required",
+				"value": null,
+				"detail": "Field 'name' failed in 'required' rule"
+			},
+			{
+				"type": "https://example.com/problems/max",
+				"pointer": "/variants/0/price",
+				"rule": "max",
+				"value": 12,
+				"accepted": "10",
+				"detail": "Field 'variants[0].price' failed in 'max' rule, available values: 10"
+			}
+		]
+	}`, rec.Body.String())
+}
+
+func TestWriteProblem_Error(t *testing.T) {
+	rec := httptest.NewRecorder()
+	validate.WriteProblem(rec, nil, validate.ErrInvalidJSON)
+
+	problem := validate.Problem{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &problem)
+
+	assert.Equal(t, validate.Problem{Title: "Bad Request", Status: http.StatusBadRequest, Detail: "invalid request"}, problem)
+}
+
+func TestWriteProblem_BodyTooLarge(t *testing.T) {
+	rec := httptest.NewRecorder()
+	validate.WriteProblem(rec, nil, validate.ErrBodyTooLarge)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"title":"Request Entity Too Large"`)
+}
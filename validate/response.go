@@ -0,0 +1,138 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	ErrUndocumentedStatus      = errors.New("undocumented response status")
+	ErrUndocumentedContentType = errors.New("undocumented response content type")
+)
+
+// StatusDefault is used for statuses not documented explicitly.
+const StatusDefault = "default"
+
+// Response holds rules of a documented response body. Status is a status
+// code, a range like 4XX or default. Response without ContentType has no
+// documented body.
+type Response struct {
+	Status               string
+	ContentType          string
+	Rules                []FieldRule
+	AdditionalProperties []FieldRule
+	Compositions         []Composition
+}
+
+// ValidateResponse checks response of a handler against documented responses
+// of operation. Only JSON bodies are validated, body of response is restored.
+func ValidateResponse(v *validator.Validate, res *http.Response, ctx context.Context, responses []Response) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	documented := matchStatus(responses, res.StatusCode)
+	if len(documented) == 0 {
+		return fmt.Errorf("%w: %d", ErrUndocumentedStatus, res.StatusCode)
+	}
+
+	hasContent := false
+	for _, response := range documented {
+		if response.ContentType != "" {
+			hasContent = true
+		}
+	}
+
+	if !hasContent {
+		return nil
+	}
+
+	contentType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+
+	var response *Response
+	for i := range documented {
+		if documented[i].ContentType == contentType {
+			response = &documented[i]
+		}
+	}
+
+	if response == nil {
+		return fmt.Errorf("%w: %d %q", ErrUndocumentedContentType, res.StatusCode, contentType)
+	}
+
+	if !isJSONMediaType(contentType) {
+		return nil
+	}
+
+	buffer, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	// restore body in response
+	res.Body = io.NopCloser(bytes.NewBuffer(buffer))
+
+	if len(bytes.TrimSpace(buffer)) == 0 {
+		buffer = []byte("null")
+	}
+
+	if !json.Valid(buffer) {
+		return ErrInvalidJSON
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(buffer))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	schema := newRootSchema(response.Rules, response.AdditionalProperties, response.Compositions)
+	if errs := schema.validateRoot(v, ctx, value, ""); len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ValidateRecorder checks response recorded by httptest.ResponseRecorder.
+func ValidateRecorder(v *validator.Validate, rec *httptest.ResponseRecorder, ctx context.Context, responses []Response) error {
+	return ValidateResponse(v, rec.Result(), ctx, responses)
+}
+
+// matchStatus returns responses documented for status code. Exact status
+// has precedence over range, which has precedence over default response.
+func matchStatus(responses []Response, statusCode int) []Response {
+	status := strconv.Itoa(statusCode)
+
+	for _, candidate := range []string{status, status[:1] + "XX", StatusDefault} {
+		var matched []Response
+		for _, response := range responses {
+			if strings.EqualFold(response.Status, candidate) {
+				matched = append(matched, response)
+			}
+		}
+
+		if len(matched) > 0 {
+			return matched
+		}
+	}
+
+	return nil
+}
+
+func isJSONMediaType(contentType string) bool {
+	return contentType == "application/json" || strings.HasSuffix(contentType, "+json")
+}
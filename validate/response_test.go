@@ -0,0 +1,93 @@
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/validate"
+)
+
+var offerResponses = []validate.Response{
+	{
+		Status:      "201",
+		ContentType: "application/json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "id", Rule: "required,string", Pattern: nil},
+			{Field: "tags", Rule: "omitempty", Pattern: nil},
+			{Field: "tags[]", Rule: "omitempty,string,max=3", Pattern: nil},
+		},
+		AdditionalProperties: []validate.FieldRule{{Field: ""}},
+	},
+	{Status: "204"},
+	{
+		Status:      "4XX",
+		ContentType: "application/problem+json",
+		Rules: []validate.FieldRule{
+			{Field: "", Rule: "required", Pattern: nil},
+			{Field: "title", Rule: "required,string", Pattern: nil},
+		},
+	},
+	{Status: "default", ContentType: "text/plain"},
+}
+
+func TestValidateRecorder(t *testing.T) {
+	testData := []struct {
+		status      int
+		contentType string
+		body        string
+		want        error
+	}{
+		{http.StatusCreated, "application/json; charset=utf-8", `{"id": "1", "tags": ["a"]}`, nil},
+		{http.StatusCreated, "application/json", `{"tags": ["a"]}`, getExpectedError("id", "required", nil, "")},
+		{http.StatusCreated, "application/json", `{"id": "1", "tags": ["abcd"]}`, getExpectedError("tags[0]", "max", "abcd", "3")},
+		{http.StatusCreated, "application/json", `{"id": "1", "name": "foo"}`, getExpectedError("name", "additionalProperties", "foo", "")},
+		{http.StatusCreated, "application/json", `{"id": `, validate.ErrInvalidJSON},
+		{http.StatusCreated, "text/html", `<p>`, validate.ErrUndocumentedContentType},
+		{http.StatusNoContent, "", ``, nil},
+		{http.StatusNotFound, "application/problem+json", `{"title": "Not Found"}`, nil},
+		{http.StatusNotFound, "application/problem+json", `{}`, getExpectedError("title", "required", nil, "")},
+		{http.StatusInternalServerError, "text/plain", `error`, nil},
+	}
+
+	for _, tt := range testData {
+		rec := httptest.NewRecorder()
+		if tt.contentType != "" {
+			rec.Header().Set("Content-Type", tt.contentType)
+		}
+		rec.WriteHeader(tt.status)
+		_, _ = rec.WriteString(tt.body)
+
+		err := validate.ValidateRecorder(NewValidator(), rec, context.Background(), offerResponses)
+
+		var errs validate.ValidationErrors
+		if errors.As(err, &errs) {
+			want, _ := tt.want.(validate.ValidationErrors)
+			for name, fieldErrors := range want {
+				if assert.Len(t, errs[name], 1, tt.body) {
+					assert.Equal(t, fieldErrors[0].Rule, errs[name][0].Rule, tt.body)
+					assert.Equal(t, fieldErrors[0].Value, errs[name][0].Value, tt.body)
+				}
+			}
+			assert.Len(t, errs, len(want), tt.body)
+
+			continue
+		}
+
+		assert.True(t, errors.Is(err, tt.want), "%s: %v", tt.body, err)
+	}
+}
+
+func TestValidateResponse_UndocumentedStatus(t *testing.T) {
+	responses := []validate.Response{{Status: "200", ContentType: "application/json"}}
+
+	res := &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}
+	err := validate.ValidateResponse(NewValidator(), res, nil, responses)
+
+	assert.True(t, errors.Is(err, validate.ErrUndocumentedStatus))
+}
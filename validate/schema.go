@@ -1,8 +1,10 @@
 package validate
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -14,16 +16,72 @@ type FieldError struct {
 	Value            interface{}
 	Accepted         string
 	ValidationErrors validator.ValidationErrors
+	// Branches are errors of failed oneOf and anyOf branches keyed by branch name
+	Branches map[string]ValidationErrors
+	// Message is a translated message, it is set when Translations are used
+	// or when spec declares x-error-message of field
+	Message string
+	// authored is set when Message comes from spec, such message is not
+	// translated
+	authored bool
+	// order is a position of field rule, errors are sorted by it
+	order int
 }
 
 func (v FieldError) Error() string {
+	if v.Message != "" {
+		return v.Message
+	}
+
 	msg := fmt.Sprintf(`Field '%s' failed in '%s' rule`, v.Field, v.Rule)
 
 	values := v.Accepted
-	if values != "" {
+	switch {
+	case values == "":
+	case v.Rule == RuleUniqueItems:
+		msg += ", duplicate items: " + values
+	case v.Rule == RuleFormat:
+		msg += ", format: " + values
+	default:
 		msg += ", available values: " + values
 	}
 
+	return msg + v.branchesMessage(FieldError.Error)
+}
+
+// branchesMessage returns errors of failed branches sorted by branch and
+// field name, formatted with message function.
+func (v FieldError) branchesMessage(message func(FieldError) string) string {
+	var msg string
+
+	if len(v.Branches) > 0 {
+		branchNames := make([]string, 0, len(v.Branches))
+		for name := range v.Branches {
+			branchNames = append(branchNames, name)
+		}
+		sort.Strings(branchNames)
+
+		var branches []string
+		for _, name := range branchNames {
+			fieldNames := make([]string, 0, len(v.Branches[name]))
+			for fieldName := range v.Branches[name] {
+				fieldNames = append(fieldNames, fieldName)
+			}
+			sort.Strings(fieldNames)
+
+			var errs []string
+			for _, fieldName := range fieldNames {
+				for _, fieldError := range v.Branches[name][fieldName] {
+					errs = append(errs, message(fieldError))
+				}
+			}
+
+			branches = append(branches, fmt.Sprintf(`'%s': %s`, name, strings.Join(errs, "; ")))
+		}
+
+		msg = " (" + strings.Join(branches, ", ") + ")"
+	}
+
 	return msg
 }
 
@@ -46,9 +104,26 @@ func (r Rules) ForBool() Rules {
 	return rr
 }
 
+// Without returns rules except rule with given name.
+func (r Rules) Without(name string) Rules {
+	rr := make(Rules, 0, len(r))
+
+	for _, rule := range r {
+		if rule != name {
+			rr = append(rr, rule)
+		}
+	}
+
+	return rr
+}
+
 func (r Rules) Required() bool {
+	return r.Has("required")
+}
+
+func (r Rules) Has(name string) bool {
 	for _, rule := range r {
-		if rule == "required" {
+		if rule == name {
 			return true
 		}
 	}
@@ -64,6 +139,8 @@ type FieldSchema struct {
 	Rules      Rules
 	Properties MapField
 	Items      FieldsArray
+	// Present is set for fields sent in request, also when their value is null
+	Present bool
 }
 
 type SliceField interface {
@@ -78,6 +155,16 @@ func (f FieldsArray) last() MapField {
 
 type MapField map[string]FieldSchema
 
+// Value returns decoded JSON object.
+func (m MapField) Value() map[string]interface{} {
+	value := make(map[string]interface{}, len(m))
+	for key, field := range m {
+		value[key] = field.Value
+	}
+
+	return value
+}
+
 func (m MapField) Get(index string) FieldSchema {
 	index = strings.Trim(index, "[]")
 
@@ -108,13 +195,26 @@ func (f FieldsArray) Get(index int) *FieldSchema {
 }
 
 func (f *FieldSchema) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
 	var r interface{}
-	_ = json.Unmarshal(data, &r)
-	switch v := r.(type) {
+	_ = decoder.Decode(&r)
+	*f = newFieldSchema(r)
+
+	return nil
+}
+
+// newFieldSchema builds field tree of decoded JSON value, so request body is
+// decoded once.
+func newFieldSchema(value interface{}) FieldSchema {
+	f := FieldSchema{Present: true, Value: value}
+
+	switch v := value.(type) {
 	case []interface{}:
 		for _, vv := range v {
 			switch vv.(type) {
-			case string, float64:
+			case string, json.Number:
 				f.Items = append(f.Items, MapField{
 					"arrayItem": FieldSchema{
 						Type:  "item",
@@ -122,33 +222,35 @@ func (f *FieldSchema) UnmarshalJSON(data []byte) error {
 						Value: vv,
 					},
 				})
-
-				break
-				//default:
-				//	fmt.Printf("Type: %T\n", vv)
 			}
 		}
 
-		//fmt.Printf("r: %#v\n", r)
 		f.Type = "array"
 		f.Name = "array"
-		f.Value = v
 
+		// array of objects, other values have no properties
 		if len(f.Items) == 0 {
-			_ = json.Unmarshal(data, &f.Items)
+			f.Items = make(FieldsArray, len(v))
+			for i, vv := range v {
+				if object, ok := vv.(map[string]interface{}); ok {
+					f.Items[i] = newMapField(object)
+				}
+			}
 		}
 	case map[string]interface{}:
-		f.Value = v
-		_ = json.Unmarshal(data, &f.Properties)
-	case float64:
-		// TODO: float64 changes max value for int64
-		f.Value = v
-	default:
-		f.Value = v
-		//fmt.Printf("%T s:%s\n", v, data)
+		f.Properties = newMapField(v)
 	}
 
-	return nil
+	return f
+}
+
+func newMapField(object map[string]interface{}) MapField {
+	fields := make(MapField, len(object))
+	for key, value := range object {
+		fields[key] = newFieldSchema(value)
+	}
+
+	return fields
 }
 
 func (f *FieldSchema) IsRequired() bool {
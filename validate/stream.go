@@ -0,0 +1,386 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	ErrBodyTooLarge = errors.New("request body too large")
+	ErrTooDeep      = errors.New("request body nested too deep")
+)
+
+// StreamConfig limits request body read by Schema.ValidateStream.
+type StreamConfig struct {
+	// MaxBodySize is a maximum size of body in bytes, 0 means no limit
+	MaxBodySize int64
+	// MaxDepth is a maximum nesting of objects and arrays, 0 means no limit
+	MaxDepth int
+	// MaxErrors stops reading of body after given number of errors, 0 means
+	// all errors are reported
+	MaxErrors int
+}
+
+type streamKey struct{}
+
+// WithStream returns context making Schema.Validate stream body with limits
+// of config, it is set by Middleware with MiddlewareConfig.Stream.
+func WithStream(ctx context.Context, config StreamConfig) context.Context {
+	return context.WithValue(ctx, streamKey{}, config)
+}
+
+// streamConfig returns limits of context set by WithStream.
+func streamConfig(ctx context.Context) (StreamConfig, bool) {
+	if ctx == nil {
+		return StreamConfig{}, false
+	}
+
+	config, ok := ctx.Value(streamKey{}).(StreamConfig)
+
+	return config, ok
+}
+
+// ValidateStream validates JSON body of request while its tokens are read.
+// Properties of objects and items of arrays are validated against the rule
+// tree as soon as they are read and then dropped, so the body is not decoded
+// whole. Values of compositions and of rules checking more than presence and
+// size of objects and arrays are read whole before they are validated.
+// Limits of body size and depth are checked while reading and reading stops
+// once MaxErrors is reached. Read bytes are kept to restore the body in
+// request for next handlers.
+func (s *Schema) ValidateStream(v *validator.Validate, req *http.Request, ctx context.Context, config StreamConfig) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	schemaValidator := s.validator(v, nil, ctx, req)
+	schemaValidator.maxErrors = config.MaxErrors
+
+	if err := schemaValidator.readStream(req, config); err != nil {
+		return err
+	}
+
+	schemaValidator.validateCompositions()
+
+	return schemaValidator.result()
+}
+
+// errStopped stops reading of body once MaxErrors is reached.
+var errStopped = errors.New("validation stopped")
+
+// readStream validates request body with limits of config while it is read.
+// Read bytes are kept, so body is restored also when reading fails or stops.
+func (s *SchemaValidator) readStream(req *http.Request, config StreamConfig) error {
+	var buffer bytes.Buffer
+	var reader io.Reader = req.Body
+	if config.MaxBodySize > 0 {
+		reader = io.LimitReader(reader, config.MaxBodySize+1)
+	}
+
+	body := req.Body
+	defer func() {
+		// unread part of body follows read bytes
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(buffer.Bytes()), body), body}
+	}()
+
+	decoder := json.NewDecoder(io.TeeReader(reader, &buffer))
+	decoder.UseNumber()
+	err := s.streamBody(decoder, config.MaxDepth)
+
+	// trailing data is not allowed
+	if err == nil {
+		if _, tokenErr := decoder.Token(); tokenErr != io.EOF {
+			err = ErrInvalidJSON
+		}
+	}
+
+	if config.MaxBodySize > 0 && int64(buffer.Len()) > config.MaxBodySize {
+		return ErrBodyTooLarge
+	}
+
+	if err == errStopped {
+		return nil
+	}
+
+	return err
+}
+
+// streamBody validates body object read from decoder. Null body is validated
+// like an empty one.
+func (s *SchemaValidator) streamBody(decoder *json.Decoder, maxDepth int) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return ErrInvalidJSON
+	}
+
+	switch token {
+	case nil:
+		s.collectValues(s.tree.root, 0, FieldsArray{nil}, s.validateField, FieldPath{})
+
+		return nil
+	case json.Delim('{'):
+		_, err := s.streamObject(decoder, s.tree.root, 0, FieldPath{}, "", 1, maxDepth)
+
+		return err
+	}
+
+	value, err := decodeToken(decoder, token, 0, maxDepth)
+	if err != nil {
+		return err
+	}
+
+	return &json.UnmarshalTypeError{Value: jsonKind(value), Type: reflect.TypeOf(map[string]interface{}{})}
+}
+
+// streamObject validates properties of object while they are read, rules of
+// node children are checked for them. Opening token of object is already
+// read. Missing properties are reported once object ends. Properties are
+// returned without their values.
+func (s *SchemaValidator) streamObject(decoder *json.Decoder, node *ruleNode, index int, path FieldPath, rulePath string, depth, maxDepth int) (map[string]interface{}, error) {
+	if maxDepth > 0 && depth > maxDepth {
+		return nil, ErrTooDeep
+	}
+
+	properties := make(map[string]interface{})
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, ErrInvalidJSON
+		}
+
+		key := token.(string)
+		properties[key] = nil
+
+		if err := s.streamProperty(decoder, node, index, path, rulePath, key, depth, maxDepth); err != nil {
+			return nil, err
+		}
+
+		if s.limitReached() {
+			return nil, errStopped
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, ErrInvalidJSON
+	}
+
+	for _, segment := range node.segments {
+		if _, ok := properties[strings.Trim(segment, "[]")]; !ok {
+			s.collectSegment(node, segment, index, FieldsArray{nil}, s.validateField, path)
+		}
+	}
+
+	return properties, nil
+}
+
+// streamProperty validates value of property under key. Objects and arrays of
+// objects having rules of their children are streamed, other values are read
+// whole. Values of compositions are kept in request body.
+func (s *SchemaValidator) streamProperty(decoder *json.Decoder, node *ruleNode, index int, path FieldPath, rulePath, key string, depth, maxDepth int) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return ErrInvalidJSON
+	}
+
+	childRulePath := joinPath(rulePath, key)
+	object, array := node.children[key], node.children[key+"[]"]
+	composed := s.isComposed(childRulePath)
+
+	var shallow interface{}
+	switch {
+	case token == json.Delim('{') && !composed && array == nil && object != nil && len(object.nested) > 0 && object.shallow:
+		shallow, err = s.streamObject(decoder, object, index+1, append(copyPath(path), key), childRulePath, depth+1, maxDepth)
+	case token == json.Delim('[') && !composed && array != nil && len(array.nested) > 0 && array.path == nil && (object == nil || len(object.nested) == 0 && object.shallow):
+		shallow, err = s.streamItems(decoder, array, index+1, path, key, childRulePath+"[]", depth+1, maxDepth)
+	default:
+		value, err := decodeToken(decoder, token, depth, maxDepth)
+		if err != nil {
+			return err
+		}
+
+		fields := MapField{key: newFieldSchema(value)}
+		if composed {
+			// composed values are properties of body, they are not streamed
+			if s.requestBody == nil {
+				s.requestBody = make(MapField)
+			}
+
+			s.requestBody[key] = fields[key]
+		}
+
+		for _, segment := range node.segments {
+			if strings.Trim(segment, "[]") == key {
+				s.collectSegment(node, segment, index, FieldsArray{fields}, s.validateField, path)
+			}
+		}
+
+		s.validateAdditionalProperty(fields, key, rulePath, path.String())
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// rule of streamed value checks its presence and size only
+	if object != nil && object.path != nil {
+		fields := MapField{key: FieldSchema{Present: true, Value: shallow}}
+		s.getValue(object.path, index, FieldsArray{fields}, s.validateField, copyPath(path))
+	}
+
+	return nil
+}
+
+// streamItems validates items of array under key while they are read, rules
+// of node children are checked for every item. Opening token of array is
+// already read. Items are returned without their values.
+func (s *SchemaValidator) streamItems(decoder *json.Decoder, node *ruleNode, index int, path FieldPath, key, rulePath string, depth, maxDepth int) ([]interface{}, error) {
+	if maxDepth > 0 && depth > maxDepth {
+		return nil, ErrTooDeep
+	}
+
+	items := []interface{}{}
+	for i := 0; decoder.More(); i++ {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, ErrInvalidJSON
+		}
+
+		itemPath := append(copyPath(path), key+"["+strconv.Itoa(i)+"]")
+		if token == json.Delim('{') {
+			_, err = s.streamObject(decoder, node, index, itemPath, rulePath, depth+1, maxDepth)
+		} else {
+			// items which are not objects have no properties
+			_, err = decodeToken(decoder, token, depth, maxDepth)
+			if err == nil {
+				s.collectValues(node, index, FieldsArray{nil}, s.validateField, itemPath)
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, nil)
+
+		if s.limitReached() {
+			return nil, errStopped
+		}
+	}
+
+	_, err := decoder.Token()
+
+	return items, toInvalidJSON(err)
+}
+
+// isComposed reports whether composition validates value under rule path, its
+// parent or its child.
+func (s *SchemaValidator) isComposed(rulePath string) bool {
+	for _, composition := range s.compositions {
+		field := composition.Field
+		if field == "" || field == rulePath ||
+			strings.HasPrefix(field, rulePath+".") || strings.HasPrefix(field, rulePath+"[]") ||
+			strings.HasPrefix(rulePath, field+".") || strings.HasPrefix(rulePath, field+"[]") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeValue reads a single value from decoder tokens.
+func decodeValue(decoder *json.Decoder, depth, maxDepth int) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, ErrInvalidJSON
+	}
+
+	return decodeToken(decoder, token, depth, maxDepth)
+}
+
+// decodeToken reads a single value starting with already read token.
+func decodeToken(decoder *json.Decoder, token json.Token, depth, maxDepth int) (interface{}, error) {
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+
+	depth++
+	if maxDepth > 0 && depth > maxDepth {
+		return nil, ErrTooDeep
+	}
+
+	switch delim {
+	case '{':
+		object := make(map[string]interface{})
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, ErrInvalidJSON
+			}
+
+			value, err := decodeValue(decoder, depth, maxDepth)
+			if err != nil {
+				return nil, err
+			}
+
+			object[key.(string)] = value
+		}
+
+		_, err := decoder.Token()
+
+		return object, toInvalidJSON(err)
+	case '[':
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeValue(decoder, depth, maxDepth)
+			if err != nil {
+				return nil, err
+			}
+
+			array = append(array, value)
+		}
+
+		_, err := decoder.Token()
+
+		return array, toInvalidJSON(err)
+	}
+
+	return nil, ErrInvalidJSON
+}
+
+func toInvalidJSON(err error) error {
+	if err != nil {
+		return ErrInvalidJSON
+	}
+
+	return nil
+}
+
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	}
+
+	return "value"
+}
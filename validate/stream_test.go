@@ -0,0 +1,147 @@
+package validate_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/validate"
+)
+
+func TestSchema_ValidateStream(t *testing.T) {
+	schema := validate.NewSchema(offerRules, offerAdditionalProperties, nil)
+	v := NewValidator()
+
+	testData := []struct {
+		name   string
+		body   string
+		config validate.StreamConfig
+		want   string
+		err    error
+	}{
+		{"valid", getOfferBody(3), validate.StreamConfig{}, "", nil},
+		{"errors", `{"categoryId": "a", "variants": [{"price": 1, "inventory": {}}], "extra": 1}`, validate.StreamConfig{},
+			"Field 'productName' failed in 'required' rule; Category id must look like 435_12; Field 'variants[0].price' failed in 'string' rule; " +
+				"Field 'variants[0].inventory.size' failed in 'required' rule; Field 'extra' failed in 'additionalProperties' rule", nil},
+		{"max errors", `{"categoryId": "a", "variants": [{"price": 1, "inventory": {}}], "extra": 1}`, validate.StreamConfig{MaxErrors: 2},
+			"Category id must look like 435_12; Field 'variants[0].price' failed in 'string' rule", nil},
+		{"max errors stop reading", `{"categoryId": "a", "productName": 1, "variants": [{"price": `, validate.StreamConfig{MaxErrors: 2},
+			"Field 'productName' failed in 'string' rule; Category id must look like 435_12", nil},
+		{"max body size", getOfferBody(3), validate.StreamConfig{MaxBodySize: 64}, "", validate.ErrBodyTooLarge},
+		{"body size within limit", getOfferBody(3), validate.StreamConfig{MaxBodySize: int64(len(getOfferBody(3)))}, "", nil},
+		{"max depth", getOfferBody(3), validate.StreamConfig{MaxDepth: 3}, "", validate.ErrTooDeep},
+		{"depth within limit", getOfferBody(3), validate.StreamConfig{MaxDepth: 4}, "", nil},
+		{"invalid", `{"productName": }`, validate.StreamConfig{}, "", validate.ErrInvalidJSON},
+		{"trailing data", `{"productName": "a"} {}`, validate.StreamConfig{}, "", validate.ErrInvalidJSON},
+		{"truncated", `{"productName": "a"`, validate.StreamConfig{}, "", validate.ErrInvalidJSON},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+			err := schema.ValidateStream(v, req, context.Background(), tt.config)
+
+			switch {
+			case tt.err != nil:
+				assert.True(t, errors.Is(err, tt.err), err)
+			case tt.want == "":
+				assert.Nil(t, err)
+			default:
+				if assert.Error(t, err) {
+					assert.Equal(t, tt.want, err.Error())
+				}
+			}
+
+			// body is restored for next handlers
+			body, _ := io.ReadAll(req.Body)
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+}
+
+func TestSchema_ValidateStream_Validate(t *testing.T) {
+	schema := validate.NewSchema(append(offerRules, validate.FieldRule{Field: "price", Rule: "omitempty"}),
+		offerAdditionalProperties, []validate.Composition{priceComposition})
+	v := NewValidator()
+
+	// streamed body is validated like the decoded one
+	bodies := []string{
+		getOfferBody(3),
+		`null`,
+		`{}`,
+		`{"categoryId": "a", "variants": [{"price": 1, "inventory": {}, "tags": ["too long tag"]}, {"price": "1", "color": "red"}], "extra": true}`,
+		`{"productName": "", "variants": {"price": "1"}, "categoryId": 1}`,
+		`{"productName": "a", "variants": [null, true, [], {}], "price": "1.0"}`,
+		`{"productName": "a", "variants": [{"inventory": null, "tags": "a"}, {"inventory": {"size": "1"}, "tags": [1, "a"]}], "price": 100}`,
+		`{"productName": "a", "variants": null, "price": true}`,
+	}
+
+	for _, body := range bodies {
+		t.Run(body, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			want := schema.Validate(v, req, context.Background())
+
+			req, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			err := schema.ValidateStream(v, req, context.Background(), validate.StreamConfig{})
+
+			if want == nil {
+				assert.Nil(t, err)
+			} else if assert.Error(t, err) {
+				assert.Equal(t, want.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func TestSchema_Validate_Stream(t *testing.T) {
+	schema := validate.NewSchema(offerRules, offerAdditionalProperties, nil)
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(getOfferBody(1)))
+
+	ctx := validate.WithStream(context.Background(), validate.StreamConfig{MaxBodySize: 16})
+	err := schema.Validate(NewValidator(), req, ctx)
+
+	assert.True(t, errors.Is(err, validate.ErrBodyTooLarge))
+}
+
+func TestMiddleware_Stream(t *testing.T) {
+	schema := validate.NewSchema(offerRules, offerAdditionalProperties, nil)
+
+	middleware := validate.Middleware(validate.MiddlewareConfig{
+		Validator: NewValidator(),
+		Routes:    []validate.Route{{Method: http.MethodPost, Path: "/offers", Validate: schema.Validate}},
+		Stream:    &validate.StreamConfig{MaxBodySize: 16},
+	})
+
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/offers", strings.NewReader(getOfferBody(1))))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func BenchmarkSchema_ValidateStream(b *testing.B) {
+	body := []byte(getOfferBody(500))
+	v := NewValidator()
+	schema := validate.NewSchema(offerRules, offerAdditionalProperties, nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+
+		if err := schema.ValidateStream(v, req, context.Background(), validate.StreamConfig{MaxDepth: 8}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
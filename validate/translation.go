@@ -0,0 +1,326 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales/de"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/pl"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+var ErrUnsupportedLocale = errors.New("unsupported locale")
+
+// Translations are used to localize messages of errors. Locale is picked from
+// Accept-Language header of validated request, messages are in English when
+// Translations are nil.
+var Translations *ut.UniversalTranslator
+
+// messages are translations of rules keyed by locale. Field name is passed
+// as {0} and accepted values as {1}. Keys with ".string" and ".items"
+// suffix are used for length of strings and arrays.
+var messages = map[string]map[string]string{
+	"en": {
+		"required":             "{0} is required",
+		RuleNullable:           "{0} must not be null",
+		"string":               "{0} must be a string",
+		"integer":              "{0} must be an integer",
+		"numeric":              "{0} must be a number",
+		"boolean":              "{0} must be a boolean",
+		"object":               "{0} must be an object",
+		"notblank":             "{0} must not be blank",
+		"ISO8601":              "{0} must be a date in ISO 8601 format",
+		"regexp":               "{0} must match pattern {1}",
+		"email":                "{0} must be a valid email address",
+		"uuid":                 "{0} must be a valid UUID",
+		"url":                  "{0} must be a valid URL",
+		"ipv4":                 "{0} must be a valid IPv4 address",
+		"ipv6":                 "{0} must be a valid IPv6 address",
+		"int32":                "{0} must be a 32-bit integer",
+		"int64":                "{0} must be a 64-bit integer",
+		"float":                "{0} must be a single precision number",
+		"double":               "{0} must be a double precision number",
+		RuleFormat:             "{0} must be a valid {1}",
+		"oneof":                "{0} must be one of: {1}",
+		"min":                  "{0} must be at least {1}",
+		"min.string":           "{0} must be at least {1} characters long",
+		"min.items":            "{0} must contain at least {1} items",
+		"max":                  "{0} must be at most {1}",
+		"max.string":           "{0} must be at most {1} characters long",
+		"max.items":            "{0} must contain at most {1} items",
+		"uniqueItems":          "{0} must contain unique items, duplicates at indexes: {1}",
+		"gt":                   "{0} must be greater than {1}",
+		"lt":                   "{0} must be less than {1}",
+		"multipleOf":           "{0} must be a multiple of {1}",
+		"additionalProperties": "{0} is not allowed",
+		KeywordOneOf:           "{0} must match exactly one of: {1}",
+		KeywordAnyOf:           "{0} must match at least one of: {1}",
+		KeywordNot:             "{0} must not match the schema",
+		"discriminator":        "{0} must be one of: {1}",
+	},
+	"pl": {
+		"required":             "{0} jest wymagane",
+		RuleNullable:           "{0} nie może być null",
+		"string":               "{0} musi być tekstem",
+		"integer":              "{0} musi być liczbą całkowitą",
+		"numeric":              "{0} musi być liczbą",
+		"boolean":              "{0} musi być wartością logiczną",
+		"object":               "{0} musi być obiektem",
+		"notblank":             "{0} nie może być puste",
+		"ISO8601":              "{0} musi być datą w formacie ISO 8601",
+		"regexp":               "{0} musi pasować do wzorca {1}",
+		"email":                "{0} musi być poprawnym adresem e-mail",
+		"uuid":                 "{0} musi być poprawnym UUID",
+		"url":                  "{0} musi być poprawnym adresem URL",
+		"ipv4":                 "{0} musi być poprawnym adresem IPv4",
+		"ipv6":                 "{0} musi być poprawnym adresem IPv6",
+		"int32":                "{0} musi być 32-bitową liczbą całkowitą",
+		"int64":                "{0} musi być 64-bitową liczbą całkowitą",
+		"float":                "{0} musi być liczbą pojedynczej precyzji",
+		"double":               "{0} musi być liczbą podwójnej precyzji",
+		RuleFormat:             "{0} musi mieć format {1}",
+		"oneof":                "{0} musi być jedną z wartości: {1}",
+		"min":                  "{0} musi wynosić co najmniej {1}",
+		"min.string":           "{0} musi mieć co najmniej {1} znaków",
+		"min.items":            "{0} musi zawierać co najmniej {1} elementów",
+		"max":                  "{0} może wynosić co najwyżej {1}",
+		"max.string":           "{0} może mieć co najwyżej {1} znaków",
+		"max.items":            "{0} może zawierać co najwyżej {1} elementów",
+		"uniqueItems":          "{0} musi zawierać unikalne elementy, duplikaty na pozycjach: {1}",
+		"gt":                   "{0} musi być większe niż {1}",
+		"lt":                   "{0} musi być mniejsze niż {1}",
+		"multipleOf":           "{0} musi być wielokrotnością {1}",
+		"additionalProperties": "{0} nie jest dozwolone",
+		KeywordOneOf:           "{0} musi pasować do dokładnie jednego z: {1}",
+		KeywordAnyOf:           "{0} musi pasować do co najmniej jednego z: {1}",
+		KeywordNot:             "{0} nie może pasować do schematu",
+		"discriminator":        "{0} musi być jedną z wartości: {1}",
+	},
+	"de": {
+		"required":             "{0} ist ein Pflichtfeld",
+		RuleNullable:           "{0} darf nicht null sein",
+		"string":               "{0} muss eine Zeichenkette sein",
+		"integer":              "{0} muss eine ganze Zahl sein",
+		"numeric":              "{0} muss eine Zahl sein",
+		"boolean":              "{0} muss ein boolescher Wert sein",
+		"object":               "{0} muss ein Objekt sein",
+		"notblank":             "{0} darf nicht leer sein",
+		"ISO8601":              "{0} muss ein Datum im ISO-8601-Format sein",
+		"regexp":               "{0} muss dem Muster {1} entsprechen",
+		"email":                "{0} muss eine gültige E-Mail-Adresse sein",
+		"uuid":                 "{0} muss eine gültige UUID sein",
+		"url":                  "{0} muss eine gültige URL sein",
+		"ipv4":                 "{0} muss eine gültige IPv4-Adresse sein",
+		"ipv6":                 "{0} muss eine gültige IPv6-Adresse sein",
+		"int32":                "{0} muss eine 32-Bit-Ganzzahl sein",
+		"int64":                "{0} muss eine 64-Bit-Ganzzahl sein",
+		"float":                "{0} muss eine Zahl einfacher Genauigkeit sein",
+		"double":               "{0} muss eine Zahl doppelter Genauigkeit sein",
+		RuleFormat:             "{0} muss im Format {1} sein",
+		"oneof":                "{0} muss einer der folgenden Werte sein: {1}",
+		"min":                  "{0} muss mindestens {1} sein",
+		"min.string":           "{0} muss mindestens {1} Zeichen lang sein",
+		"min.items":            "{0} muss mindestens {1} Elemente enthalten",
+		"max":                  "{0} darf höchstens {1} sein",
+		"max.string":           "{0} darf höchstens {1} Zeichen lang sein",
+		"max.items":            "{0} darf höchstens {1} Elemente enthalten",
+		"uniqueItems":          "{0} muss eindeutige Elemente enthalten, Duplikate an Positionen: {1}",
+		"gt":                   "{0} muss größer als {1} sein",
+		"lt":                   "{0} muss kleiner als {1} sein",
+		"multipleOf":           "{0} muss ein Vielfaches von {1} sein",
+		"additionalProperties": "{0} ist nicht erlaubt",
+		KeywordOneOf:           "{0} muss genau einem der folgenden Schemas entsprechen: {1}",
+		KeywordAnyOf:           "{0} muss mindestens einem der folgenden Schemas entsprechen: {1}",
+		KeywordNot:             "{0} darf dem Schema nicht entsprechen",
+		"discriminator":        "{0} muss einer der folgenden Werte sein: {1}",
+	},
+}
+
+// RegisterTranslations adds messages of built-in and custom rules to
+// translator and registers them in validator for rules which are validator
+// tags. Translator locale has to be English, Polish or German.
+func RegisterTranslations(v *validator.Validate, trans ut.Translator) error {
+	localeMessages, ok := messages[baseLocale(trans.Locale())]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnsupportedLocale, trans.Locale())
+	}
+
+	keys := make([]string, 0, len(localeMessages))
+	for key := range localeMessages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := trans.Add(key, localeMessages[key], true); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range keys {
+		// length variants are picked by translation of base rule
+		if strings.Contains(key, ".") {
+			continue
+		}
+
+		err := v.RegisterTranslation(key, trans, func(ut.Translator) error {
+			return nil
+		}, func(trans ut.Translator, fe validator.FieldError) string {
+			return translate(trans, fe.Tag(), fe.Field(), fe.Param(), fe.Value())
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewTranslations returns translator of English, Polish and German messages
+// with English as a fallback.
+func NewTranslations(v *validator.Validate) (*ut.UniversalTranslator, error) {
+	english := en.New()
+	uni := ut.New(english, english, pl.New(), de.New())
+
+	for _, locale := range []string{"en", "pl", "de"} {
+		trans, _ := uni.GetTranslator(locale)
+		if err := RegisterTranslations(v, trans); err != nil {
+			return nil, err
+		}
+	}
+
+	return uni, nil
+}
+
+// RequestTranslator returns translator of the most preferred language from
+// Accept-Language header of request, or the fallback one.
+func RequestTranslator(uni *ut.UniversalTranslator, req *http.Request) ut.Translator {
+	trans, _ := uni.FindTranslator(AcceptLanguage(req.Header.Get("Accept-Language"))...)
+
+	return trans
+}
+
+// requestTranslator returns translator for request when Translations are
+// used.
+func requestTranslator(req *http.Request) ut.Translator {
+	if Translations == nil || req == nil {
+		return nil
+	}
+
+	return RequestTranslator(Translations, req)
+}
+
+// AcceptLanguage returns locales of Accept-Language header ordered by their
+// quality, e.g. "pl-PL,de;q=0.5" gives pl_PL, pl and de.
+func AcceptLanguage(header string) []string {
+	type language struct {
+		locale  string
+		quality float64
+	}
+
+	var languages []language
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(strings.TrimSpace(part), ";")
+		locale := strings.TrimSpace(params[0])
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			if value := strings.TrimSpace(param); strings.HasPrefix(value, "q=") {
+				quality, _ = strconv.ParseFloat(value[2:], 64)
+			}
+		}
+
+		if quality > 0 {
+			languages = append(languages, language{strings.ReplaceAll(locale, "-", "_"), quality})
+		}
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	var locales []string
+	for _, language := range languages {
+		locales = append(locales, language.locale)
+		if base := baseLocale(language.locale); base != language.locale {
+			locales = append(locales, base)
+		}
+	}
+
+	return locales
+}
+
+// Translate returns message of error in language of translator. English
+// message is returned when translator has no translation of rule. Messages
+// declared in spec are returned as they are.
+func (v FieldError) Translate(trans ut.Translator) string {
+	if v.authored {
+		return v.Message
+	}
+
+	message := translate(trans, v.Rule, v.Field, v.Accepted, v.Value)
+	if message == "" {
+		return v.Error()
+	}
+
+	return message + v.branchesMessage(func(fieldError FieldError) string {
+		return fieldError.Translate(trans)
+	})
+}
+
+// Translate returns messages of errors in order of Sorted.
+func (vErrors ValidationErrors) Translate(trans ut.Translator) []string {
+	sorted := vErrors.Sorted()
+
+	translated := make([]string, 0, len(sorted))
+	for _, fieldError := range sorted {
+		translated = append(translated, fieldError.Translate(trans))
+	}
+
+	return translated
+}
+
+// translate sets messages of errors using translator.
+func (vErrors ValidationErrors) translate(trans ut.Translator) {
+	for _, fieldErrors := range vErrors {
+		for i := range fieldErrors {
+			fieldErrors[i].Message = fieldErrors[i].Translate(trans)
+		}
+	}
+}
+
+func translate(trans ut.Translator, rule, field, accepted string, value interface{}) string {
+	key := rule
+	if rule == "min" || rule == "max" {
+		switch value.(type) {
+		case string:
+			key += ".string"
+		case []interface{}:
+			key += ".items"
+		}
+	}
+
+	message, err := trans.T(key, field, accepted)
+	if err != nil {
+		return ""
+	}
+
+	return message
+}
+
+func baseLocale(locale string) string {
+	if position := strings.IndexAny(locale, "_-"); position >= 0 {
+		return locale[:position]
+	}
+
+	return locale
+}
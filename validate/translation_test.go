@@ -0,0 +1,108 @@
+package validate_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/locales/fr"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beng90/spec2go/validate"
+)
+
+func TestAcceptLanguage(t *testing.T) {
+	testData := map[string][]string{
+		"":                          nil,
+		"pl":                        {"pl"},
+		"pl-PL,de;q=0.5":            {"pl_PL", "pl", "de"},
+		"de;q=0.2, en;q=0.8, *":     {"en", "de"},
+		"fr-CH, fr;q=0.9, pl;q=0":   {"fr_CH", "fr", "fr"},
+		"en-US;q=0.5,pl-PL;q=0.9,x": {"x", "pl_PL", "pl", "en_US", "en"},
+	}
+
+	for header, want := range testData {
+		assert.Equal(t, want, validate.AcceptLanguage(header), header)
+	}
+}
+
+func TestSchemaValidator_Validate_Translated(t *testing.T) {
+	v := NewValidator()
+
+	translations, err := validate.NewTranslations(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validate.Translations = translations
+	defer func() {
+		validate.Translations = nil
+	}()
+
+	testData := []struct {
+		language string
+		want     []string
+	}{
+		{"pl-PL,en;q=0.5", []string{"name jest wymagane", "code musi mieć co najmniej 3 znaków", "Size must be a whole number"}},
+		{"de", []string{"name ist ein Pflichtfeld", "code muss mindestens 3 Zeichen lang sein", "Size must be a whole number"}},
+		{"fr", []string{"name is required", "code must be at least 3 characters long", "Size must be a whole number"}},
+	}
+
+	for _, tt := range testData {
+		req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"code": "ab", "size": "x"}`))
+		req.Header.Set("Accept-Language", tt.language)
+
+		schemaValidator, _ := validate.NewSchemaValidator(v, req, context.Background())
+		schemaValidator.AddRule("name", "required,string", nil)
+		schemaValidator.AddRule("code", "required,string,min=3", nil)
+		schemaValidator.AddRule("size", "required,integer", nil)
+		schemaValidator.AddMessages("size", map[string]string{"integer": "Size must be a whole number"})
+
+		err := schemaValidator.Validate()
+
+		var errs validate.ValidationErrors
+		if assert.True(t, errors.As(err, &errs), tt.language) {
+			var messages []string
+			for _, fieldError := range errs.Sorted() {
+				messages = append(messages, fieldError.Error())
+			}
+
+			assert.Equal(t, tt.want, messages, tt.language)
+		}
+	}
+}
+
+func TestFieldError_Translate(t *testing.T) {
+	translations, err := validate.NewTranslations(NewValidator())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trans, _ := translations.GetTranslator("pl")
+
+	fieldError := validate.FieldError{
+		Field:    "price",
+		Rule:     validate.KeywordOneOf,
+		Accepted: "Price, Cents",
+		Branches: map[string]validate.ValidationErrors{
+			"Cents": {"price": {{Field: "price", Rule: "integer"}}},
+			"Price": {"price": {{Field: "price", Rule: "regexp", Accepted: `^\d+$`}}},
+		},
+	}
+
+	assert.Equal(t, `price musi pasować do dokładnie jednego z: Price, Cents ('Cents': price musi być liczbą całkowitą, 'Price': price musi pasować do wzorca ^\d+$)`, fieldError.Translate(trans))
+	assert.Equal(t, "Field 'price' failed in 'unknown' rule", validate.FieldError{Field: "price", Rule: "unknown"}.Translate(trans))
+	assert.Equal(t, "tags musi zawierać unikalne elementy, duplikaty na pozycjach: 0, 2", validate.FieldError{Field: "tags", Rule: "uniqueItems", Accepted: "0, 2"}.Translate(trans))
+	assert.Equal(t, []string{"price jest wymagane"}, validate.ValidationErrors{"price": {{Field: "price", Rule: "required"}}}.Translate(trans))
+}
+
+func TestRegisterTranslations_UnsupportedLocale(t *testing.T) {
+	french := fr.New()
+	trans, _ := ut.New(french, french).GetTranslator("fr")
+
+	err := validate.RegisterTranslations(NewValidator(), trans)
+	assert.True(t, errors.Is(err, validate.ErrUnsupportedLocale))
+}
@@ -0,0 +1,126 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// duplicateItems returns indexes of array items equal to another item. Items
+// are compared with deep JSON equality, so objects with keys in different
+// order and numbers written differently, e.g. 1 and 1.0, are equal.
+func duplicateItems(items []interface{}) []int {
+	indexes := make(map[string][]int, len(items))
+	for i, item := range items {
+		var key strings.Builder
+		canonicalJSON(&key, item)
+
+		indexes[key.String()] = append(indexes[key.String()], i)
+	}
+
+	var duplicates []int
+	for _, group := range indexes {
+		if len(group) > 1 {
+			duplicates = append(duplicates, group...)
+		}
+	}
+	sort.Ints(duplicates)
+
+	return duplicates
+}
+
+// canonicalJSON writes value with sorted object keys and exact numbers.
+func canonicalJSON(b *strings.Builder, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		b.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(strconv.Quote(key))
+			b.WriteByte(':')
+			canonicalJSON(b, v[key])
+		}
+		b.WriteByte('}')
+	case []interface{}:
+		b.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			canonicalJSON(b, item)
+		}
+		b.WriteByte(']')
+	case json.Number:
+		b.WriteString(canonicalNumber(v.String()))
+	case float64:
+		b.WriteString(canonicalNumber(strconv.FormatFloat(v, 'g', -1, 64)))
+	case string:
+		b.WriteString(strconv.Quote(v))
+	case nil:
+		b.WriteString("null")
+	default:
+		fmt.Fprint(b, v)
+	}
+}
+
+// canonicalNumber returns decimal number as its significant digits and
+// exponent, e.g. 1.50 and 15e-1 are both 15e-1. Exponent is not expanded, so
+// numbers like 1e999999 of untrusted body stay short. Text which is not a
+// number is returned as it is.
+func canonicalNumber(text string) string {
+	number := text
+
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+
+	var exponent int64
+	if i := strings.IndexAny(number, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(number[i+1:], 10, 64)
+		if err != nil {
+			return text
+		}
+		exponent, number = e, number[:i]
+	}
+
+	digits := number
+	if i := strings.IndexByte(number, '.'); i >= 0 {
+		digits = number[:i] + number[i+1:]
+		exponent -= int64(len(number) - i - 1)
+	}
+
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return text
+	}
+
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return "0"
+	}
+
+	trimmed := strings.TrimRight(digits, "0")
+	exponent += int64(len(digits) - len(trimmed))
+
+	return sign + trimmed + "e" + strconv.FormatInt(exponent, 10)
+}
+
+// indexesList formats indexes as accepted values of error.
+func indexesList(indexes []int) string {
+	list := make([]string, len(indexes))
+	for i, index := range indexes {
+		list[i] = strconv.Itoa(index)
+	}
+
+	return strings.Join(list, ", ")
+}
@@ -4,6 +4,9 @@ import (
 	"errors"
 	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 
@@ -14,19 +17,57 @@ var (
 	ErrInvalidJSON = errors.New("invalid json")
 )
 
+// RuleNullable allows null value of field. Null values are checked by
+// SchemaValidator, so the rule itself accepts any value.
+const RuleNullable = "nullable"
+
+// RuleUniqueItems rejects arrays with equal items. Items are compared by
+// SchemaValidator and ParameterValidator, which report indexes of duplicates,
+// so the rule itself accepts any value.
+const RuleUniqueItems = "uniqueItems"
+
+// customValidations are rules registered by RegisterCustomValidations.
+var customValidations = map[string]validator.Func{
+	"ISO8601":       IsISO8601Date,
+	"boolean":       validations.IsBoolean,
+	"string":        validations.IsString,
+	"integer":       IsInteger,
+	"int32":         IsInt32,
+	"int64":         IsInt64,
+	"float":         IsFloat,
+	"double":        IsDouble,
+	"multipleOf":    IsMultipleOf,
+	RuleFormat:      IsFormat,
+	"object":        IsObject,
+	"notblank":      validations.NotBlank,
+	"oneof":         validations.IsOneOf,
+	RuleNullable:    IsNullable,
+	RuleUniqueItems: IsUniqueItems,
+}
+
 func RegisterCustomValidations(validator *validator.Validate) {
-	_ = validator.RegisterValidation("ISO8601", IsISO8601Date)
-	_ = validator.RegisterValidation("boolean", validations.IsBoolean)
-	_ = validator.RegisterValidation("string", validations.IsString)
-	_ = validator.RegisterValidation("integer", IsNumber)
-	_ = validator.RegisterValidation("object", IsObject)
-	_ = validator.RegisterValidation("notblank", validations.NotBlank)
+	for tag, fn := range customValidations {
+		_ = validator.RegisterValidation(tag, fn)
+	}
 }
 
-func IsISO8601Date(fl validator.FieldLevel) bool {
-	ISO8601DateRegexString := "^(-?(?:[1-9][0-9]*)?[0-9]{4})-(1[0-2]|0[1-9])-(3[01]|0[1-9]|[12][0-9])(?:T|\\s)(2[0-3]|[01][0-9]):([0-5][0-9]):([0-5][0-9])?(Z)?$"
-	ISO8601DateRegex := regexp.MustCompile(ISO8601DateRegexString)
+// CustomValidations returns sorted names of rules registered by
+// RegisterCustomValidations.
+func CustomValidations() []string {
+	names := make([]string, 0, len(customValidations))
+	for tag := range customValidations {
+		names = append(names, tag)
+	}
+	sort.Strings(names)
 
+	return names
+}
+
+const ISO8601DateRegexString = "^(-?(?:[1-9][0-9]*)?[0-9]{4})-(1[0-2]|0[1-9])-(3[01]|0[1-9]|[12][0-9])(?:T|\\s)(2[0-3]|[01][0-9]):([0-5][0-9]):([0-5][0-9])?(Z)?$"
+
+var ISO8601DateRegex = regexp.MustCompile(ISO8601DateRegexString)
+
+func IsISO8601Date(fl validator.FieldLevel) bool {
 	return ISO8601DateRegex.MatchString(fl.Field().String())
 }
 
@@ -40,6 +81,16 @@ func IsNumber(fl validator.FieldLevel) bool {
 	return false
 }
 
+func IsNullable(fl validator.FieldLevel) bool {
+	return true
+}
+
+// IsUniqueItems accepts any value, duplicate items are found by
+// ValidationErrors.unique, which reports their indexes.
+func IsUniqueItems(fl validator.FieldLevel) bool {
+	return true
+}
+
 func IsObject(fl validator.FieldLevel) bool {
 	if fl.Field().Kind() == reflect.Map {
 		return true
@@ -50,20 +101,167 @@ func IsObject(fl validator.FieldLevel) bool {
 
 type ValidationErrors map[string][]FieldError
 
+// Error returns messages of all errors in order of Sorted.
 func (vErrors ValidationErrors) Error() string {
-	return ""
+	sorted := vErrors.Sorted()
+
+	messages := make([]string, 0, len(sorted))
+	for _, fieldError := range sorted {
+		messages = append(messages, fieldError.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Sorted returns errors ordered by position of field in spec, then by array
+// indexes and then by order of rules. Fields without rule, e.g. additional
+// properties, are placed last.
+func (vErrors ValidationErrors) Sorted() []FieldError {
+	names := make([]string, 0, len(vErrors))
+	for name := range vErrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sorted []FieldError
+	for _, name := range names {
+		sorted = append(sorted, vErrors[name]...)
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].order != sorted[j].order {
+			return sorted[i].order < sorted[j].order
+		}
+
+		left, right := arrayIndexes(sorted[i].Field), arrayIndexes(sorted[j].Field)
+		for k := 0; k < len(left) && k < len(right); k++ {
+			if left[k] != right[k] {
+				return left[k] < right[k]
+			}
+		}
+
+		if len(left) != len(right) {
+			return len(left) < len(right)
+		}
+
+		return sorted[i].Field < sorted[j].Field
+	})
+
+	return sorted
+}
+
+// setOrder sets position of every error using position of its field rule.
+func (vErrors ValidationErrors) setOrder(position func(fieldName string) (int, bool)) {
+	last := 0
+	orders := make(map[string]int, len(vErrors))
+
+	for name := range vErrors {
+		if order, ok := position(name); ok {
+			orders[name] = order
+			if order >= last {
+				last = order + 1
+			}
+		}
+	}
+
+	for name, fieldErrors := range vErrors {
+		order, ok := orders[name]
+		if !ok {
+			order = last
+		}
+
+		for i := range fieldErrors {
+			fieldErrors[i].order = order
+		}
+	}
+}
+
+// setMessages sets messages of errors declared in spec for their field and
+// rule.
+func (vErrors ValidationErrors) setMessages(message func(fieldName, rule string) (string, bool)) {
+	for name, fieldErrors := range vErrors {
+		for i := range fieldErrors {
+			if msg, ok := message(name, fieldErrors[i].Rule); ok {
+				fieldErrors[i].Message = msg
+				fieldErrors[i].authored = true
+			}
+		}
+	}
+}
+
+// ruleMessage returns message of rule, or message of any rule keyed by empty
+// string.
+func ruleMessage(messages map[string]string, rule string) (string, bool) {
+	if msg, ok := messages[rule]; ok {
+		return msg, true
+	}
+
+	msg, ok := messages[""]
+
+	return msg, ok
+}
+
+// merge appends errors of other validator, they are sorted after own errors.
+func (vErrors ValidationErrors) merge(other ValidationErrors) {
+	last := 0
+	for _, fieldErrors := range vErrors {
+		for _, fieldError := range fieldErrors {
+			if fieldError.order >= last {
+				last = fieldError.order + 1
+			}
+		}
+	}
+
+	for name, fieldErrors := range other {
+		for _, fieldError := range fieldErrors {
+			fieldError.order += last
+			vErrors[name] = append(vErrors[name], fieldError)
+		}
+	}
+}
+
+var arrayIndexRegexp = regexp.MustCompile(`\[(\d+)\]`)
+
+func arrayIndexes(fieldName string) []int {
+	var indexes []int
+	for _, match := range arrayIndexRegexp.FindAllStringSubmatch(fieldName, -1) {
+		index, _ := strconv.Atoi(match[1])
+		indexes = append(indexes, index)
+	}
+
+	return indexes
 }
 
 func (vErrors ValidationErrors) try(fieldName string, err error) {
 	if err != nil {
 		e := err.(validator.ValidationErrors)
 
+		accepted := e[0].Param()
+		if e[0].Tag() == "oneof" {
+			accepted = strings.Join(validations.ParseOneOf(accepted), ", ")
+		}
+
 		vErrors[fieldName] = append(vErrors[fieldName], FieldError{
 			Field:            fieldName,
 			Rule:             e[0].Tag(),
 			Value:            e[0].Value(),
-			Accepted:         e[0].Param(),
+			Accepted:         accepted,
 			ValidationErrors: e,
 		})
 	}
 }
+
+// unique reports items of array which are not unique.
+func (vErrors ValidationErrors) unique(fieldName string, items []interface{}) {
+	duplicates := duplicateItems(items)
+	if len(duplicates) == 0 {
+		return
+	}
+
+	vErrors[fieldName] = append(vErrors[fieldName], FieldError{
+		Field:    fieldName,
+		Rule:     RuleUniqueItems,
+		Value:    plainValue(items[duplicates[0]]),
+		Accepted: indexesList(duplicates),
+	})
+}
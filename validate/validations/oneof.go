@@ -0,0 +1,85 @@
+package validations
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// IsOneOf is the validation function for validating if the current field
+// value is one of the space separated values in the param. Unlike the
+// built-in oneof it accepts float and boolean fields, as these are the types
+// JSON values are decoded into.
+func IsOneOf(fl validator.FieldLevel) bool {
+	field := fl.Field()
+
+	var v string
+	switch field.Kind() {
+	case reflect.String:
+		v = field.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v = strconv.FormatInt(field.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v = strconv.FormatUint(field.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		v = strconv.FormatFloat(field.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		v = strconv.FormatBool(field.Bool())
+	default:
+		return false
+	}
+
+	for _, value := range ParseOneOf(fl.Param()) {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseOneOf splits oneof param into values. Values containing spaces are
+// wrapped in single quotes, a single quote inside such value is doubled.
+func ParseOneOf(param string) []string {
+	var values []string
+
+	for i := 0; i < len(param); {
+		switch {
+		case param[i] == ' ':
+			i++
+		case param[i] == '\'':
+			var value strings.Builder
+
+			for i++; i < len(param); i++ {
+				if param[i] == '\'' {
+					if i+1 < len(param) && param[i+1] == '\'' {
+						value.WriteByte('\'')
+						i++
+
+						continue
+					}
+
+					i++
+
+					break
+				}
+
+				value.WriteByte(param[i])
+			}
+
+			values = append(values, value.String())
+		default:
+			end := strings.IndexByte(param[i:], ' ')
+			if end < 0 {
+				end = len(param) - i
+			}
+
+			values = append(values, param[i:i+end])
+			i += end
+		}
+	}
+
+	return values
+}
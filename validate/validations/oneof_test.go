@@ -0,0 +1,32 @@
+package validations
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestParseOneOf(t *testing.T) {
+	assert.Equal(t, []string{"active", "inactive"}, ParseOneOf("active inactive"))
+	assert.Equal(t, []string{"on hold", "it's", "", "a,b"}, ParseOneOf("'on hold' 'it''s' '' a,b"))
+}
+
+func TestIsOneOf(t *testing.T) {
+	v := validator.New()
+	err := v.RegisterValidation("oneof", IsOneOf)
+	assert.Equal(t, nil, err)
+
+	valid := []interface{}{"active", "on hold", "a,b", "a|b", float64(10), int64(20), true}
+	for _, value := range valid {
+		err = v.Var(value, "oneof=active 'on hold' a0x2Cb a0x7Cb 10 20 true")
+		assert.Equal(t, nil, err)
+	}
+
+	invalid := []interface{}{"hold", "a", float64(10.5), false, []string{"active"}}
+	for _, value := range invalid {
+		err = v.Var(value, "oneof=active 'on hold' a0x2Cb a0x7Cb 10 20 true")
+		assert.NotEqual(t, nil, err)
+	}
+}
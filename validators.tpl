@@ -1,37 +1,127 @@
-package openapi
+package {{ .Package }}
 
 import (
-    "context"
-    "github.com/beng90/spec2go/validate"
-	"github.com/go-playground/validator/v10"
+{{- if .HasValidators }}
+	"context"
 	"net/http"
+{{- end }}
+
+	"github.com/beng90/spec2go/validate"
+{{- if .HasValidators }}
+	"github.com/go-playground/validator/v10"
+{{- end }}
 )
 
-type ValidationRule struct {
-	Field   string
-	Rule    string
-	Pattern *string
-}
-{{ range . }}{{ if .Parameters }}
-var {{ .Name }}Rules = []ValidationRule{
-    {{- range $parameter := .Parameters }}
-    {{- if .Rules.String }}
-    {"{{ $parameter.Name }}", "{{ .Rules }}", {{- if .Pattern }}validate.Pattern(`{{ .Pattern }}`){{- else }}nil{{- end}}},
-    {{- end }}{{ end }}
+type ParameterRule struct {
+	Name     string
+	In       string
+	Rule     string
+	ItemRule string
+	Pattern  *string
 }
+{{ range .Validators }}{{ if .HasBody }}
+var {{ .Name }}Schema = validate.NewSchema(
+    {{ if .Parameters }}{{ template "fieldRules" .Parameters }}{{ else }}nil{{ end }},
+    {{ if .AdditionalProperties }}{{ template "additionalRules" .AdditionalProperties }}{{ else }}nil{{ end }},
+    {{ if .Compositions }}{{ template "compositions" .Compositions }}{{ else }}nil{{ end }},
+)
 
 func {{ .Name }}(v *validator.Validate, req *http.Request, ctx context.Context) error {
-	schemaValidator, err := validate.NewSchemaValidator(v, req, ctx)
-    if err != nil {
-        return err
-    }
+	return {{ .Name }}Schema.Validate(v, req, ctx)
+}
+{{ end }}{{ if .RequestParameters }}
+var {{ .Name }}ParametersRules = []ParameterRule{
+    {{- range $parameter := .RequestParameters.Ordered }}
+    {{- $pattern := .Pattern }}{{ if .Items }}{{ $pattern = .Items.Pattern }}{{ end }}
+    {"{{ .Name }}", "{{ .In }}", {{ printf "%q" .Rules.String }}, {{ if .Items }}{{ printf "%q" .Items.Rules.String }}{{ else }}""{{ end }}, {{- if $pattern }}validate.Pattern(`{{ $pattern }}`){{- else }}nil{{- end}}},
+    {{- end }}
+}
+{{ if .RequestParameters.Messages }}
+var {{ .Name }}ParametersMessages = {{ template "messages" .RequestParameters.Messages }}
+{{ end }}
+func {{ .Name }}Parameters(v *validator.Validate, req *http.Request, ctx context.Context) error {
+	parameterValidator := validate.NewParameterValidator(v, req, ctx, {{ printf "%q" .Path }})
 
-    for _, vRule := range {{ .Name }}Rules {
-        schemaValidator.AddRule(vRule.Field, vRule.Rule, vRule.Pattern)
+    for _, pRule := range {{ .Name }}ParametersRules {
+        parameterValidator.AddRule(pRule.Name, pRule.In, pRule.Rule, pRule.ItemRule, pRule.Pattern)
     }
+{{ if .RequestParameters.Messages }}
+    for name, messages := range {{ .Name }}ParametersMessages {
+        parameterValidator.AddMessages(name, messages)
+    }
+{{ end }}
+	return parameterValidator.Validate()
+}
+{{ end }}{{ if .Responses }}
+var {{ .Name }}Responses = []validate.Response{
+    {{- range .Responses }}
+    {
+        Status:               {{ printf "%q" .Status }},
+        ContentType:          {{ printf "%q" .ContentType }},
+        Rules:                {{ if .Properties }}{{ template "fieldRules" .Properties }}{{ else }}nil{{ end }},
+        AdditionalProperties: {{ if .AdditionalProperties }}{{ template "additionalRules" .AdditionalProperties }}{{ else }}nil{{ end }},
+        Compositions:         {{ if .Compositions }}{{ template "compositions" .Compositions }}{{ else }}nil{{ end }},
+    },
+    {{- end }}
+}
 
-	err = schemaValidator.Validate()
-
-    return err
+func {{ .Name }}Response(v *validator.Validate, res *http.Response, ctx context.Context) error {
+	return validate.ValidateResponse(v, res, ctx, {{ .Name }}Responses)
 }
 {{ end }}{{ end }}
+// Routes maps operations to their validators, it is used by validate.Middleware.
+var Routes = []validate.Route{
+    {{- range .Validators }}
+    {Method: {{ printf "%q" .Method }}, Path: {{ printf "%q" .Path }}{{ if .HasBody }}, Validate: {{ .Name }}{{ end }}{{ if .RequestParameters }}, ValidateParameters: {{ .Name }}Parameters{{ end }}},
+    {{- end }}
+}
+{{ define "fieldRules" }}[]validate.FieldRule{
+    {{- range $parameter := .Ordered }}
+    {Field: {{ printf "%q" $parameter.Name }}, Rule: {{ printf "%q" $parameter.Rules.String }}, Pattern: {{ if $parameter.Pattern }}validate.Pattern(`{{ $parameter.Pattern }}`){{ else }}nil{{ end }}{{ with $parameter.Messages }}, Messages: {{ template "ruleMessages" . }}{{ end }}},
+    {{- end }}
+}{{ end }}
+{{ define "messages" }}map[string]map[string]string{
+    {{- range $field, $messages := . }}
+    {{ printf "%q" $field }}: {
+        {{- range $rule, $message := $messages }}
+        {{ printf "%q" $rule }}: {{ printf "%q" $message }},
+        {{- end }}
+    },
+    {{- end }}
+}{{ end }}
+{{ define "ruleMessages" }}map[string]string{
+    {{- range $rule, $message := . }}
+    {{ printf "%q" $rule }}: {{ printf "%q" $message }},
+    {{- end }}
+}{{ end }}
+{{ define "additionalRules" }}[]validate.FieldRule{
+    {{- range $field, $parameter := . }}
+    {Field: {{ printf "%q" $field }}{{ if $parameter }}, Rule: {{ printf "%q" $parameter.Rules.String }}{{ if $parameter.Pattern }}, Pattern: validate.Pattern(`{{ $parameter.Pattern }}`){{ end }}{{ end }}},
+    {{- end }}
+}{{ end }}
+{{ define "compositions" }}[]validate.Composition{
+    {{- range . }}
+    {
+        Field:   {{ printf "%q" .Field }},
+        Keyword: {{ printf "%q" .Keyword }},
+        {{- if .Discriminator }}
+        Discriminator: {{ printf "%q" .Discriminator }},
+        Mapping: map[string]string{
+            {{- range $value, $branch := .Mapping }}
+            {{ printf "%q" $value }}: {{ printf "%q" $branch }},
+            {{- end }}
+        },
+        {{- end }}
+        Branches: []validate.Branch{
+            {{- range .Branches }}
+            {
+                Name:                 {{ printf "%q" .Name }},
+                Rules:                {{ if .Properties }}{{ template "fieldRules" .Properties }}{{ else }}nil{{ end }},
+                AdditionalProperties: {{ if .AdditionalProperties }}{{ template "additionalRules" .AdditionalProperties }}{{ else }}nil{{ end }},
+                Compositions:         {{ if .Compositions }}{{ template "compositions" .Compositions }}{{ else }}nil{{ end }},
+            },
+            {{- end }}
+        },
+    },
+    {{- end }}
+}{{ end }}